@@ -0,0 +1,89 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance"
+	"github.com/spf13/cobra"
+)
+
+type explainFlags struct {
+	mode       string
+	configFile string
+	testFiles  []string
+}
+
+func newExplainCommand() *cobra.Command {
+	flagset := &explainFlags{}
+	cmd := &cobra.Command{
+		Use:   "explain --mode [client|server|both] [--conf config.yaml] pattern...",
+		Short: "Explains which config cases apply to the given test suites or cases.",
+		Long: `Explains why the given test suites or test cases are or are not run for the
+config cases described by the --conf file (or the default config, if no file is
+given). The positional arguments are patterns that match suite names (such as
+"Basic") or test case names, which are the suite name followed by the name of
+the test case as defined in the suite (such as "Basic/unary/success").
+
+For each matching suite or test case, every config case is printed along with
+whether it is included. If a config case is excluded, the feature or suite
+constraint that excludes it is also printed.
+`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runExplain(flagset, args)
+		},
+	}
+	cmd.Flags().StringVar(&flagset.mode, modeFlagName, "",
+		"required: the mode of the test to explain; must be 'client', 'server', or 'both'")
+	cmd.Flags().StringVar(&flagset.configFile, configFlagName, "",
+		"a config file in YAML format with supported features")
+	cmd.Flags().StringArrayVar(&flagset.testFiles, testFileFlagName, nil,
+		"a file in YAML format containing tests to explain, which will skip the embedded tests; can be specified more than once")
+	return cmd
+}
+
+func runExplain(flags *explainFlags, args []string) {
+	fatal := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+		os.Exit(1)
+	}
+
+	switch flags.mode {
+	case "client", "server", "both":
+	default:
+		fatal(`Invalid mode: expecting "client", "server", or "both"; got %q`, flags.mode)
+	}
+	patterns, err := argsToPatterns(args)
+	if err != nil {
+		fatal("%s", err)
+	}
+
+	err = connectconformance.Explain(
+		&connectconformance.ExplainFlags{
+			Mode:       flags.mode,
+			ConfigFile: flags.configFile,
+			TestFiles:  flags.testFiles,
+			Patterns:   patterns,
+		},
+		internal.NewPrinter(os.Stdout),
+	)
+	if err != nil {
+		fatal("%s", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance"
+	"github.com/spf13/cobra"
+)
+
+func newLintSuitesCommand() *cobra.Command {
+	var testFiles []string
+	cmd := &cobra.Command{
+		Use:   "lint-suites [--test-file file.yaml]...",
+		Short: "Checks test suite definitions for mistakes.",
+		Long: `Checks test suite definitions for mistakes without running any tests. This
+reports duplicate test names, request messages whose type does not match the
+stream type, raw requests and responses in suites whose mode means they are
+never used, relevant_* lists that contradict relies_on_* flags, expand_requests
+entries that don't line up with the request messages, and response definitions
+whose expected response can never be satisfied. Each problem is reported along
+with the line in the file that caused it.
+
+If no --test-file options are given, the test suites that are embedded in the
+test runner are checked.
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ok, err := connectconformance.LintSuites(testFiles, internal.NewPrinter(os.Stdout))
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(1)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringArrayVar(&testFiles, testFileFlagName, nil, "a file in YAML format containing tests to check, instead of the embedded tests")
+	return cmd
+}
@@ -0,0 +1,126 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance"
+	"github.com/spf13/cobra"
+)
+
+const jsonFlagName = "json"
+
+type listFlags struct {
+	mode         string
+	configFile   string
+	testFiles    []string
+	runPatterns  []string
+	skipPatterns []string
+	tags         []string
+	skipTags     []string
+	dimensions   connectconformance.DimensionFlags
+	json         bool
+	shardIndex   uint
+	shardCount   uint
+}
+
+func newListCommand() *cobra.Command {
+	flagset := &listFlags{}
+	cmd := &cobra.Command{
+		Use:   "list --mode [client|server|both]",
+		Short: "Lists the test cases that would be run, without running them.",
+		Long: `Lists the names of the test case permutations that would be run with the
+given flags, one per line, without starting any clients or servers. The
+--conf, --test-file, --run, --skip, --tag, --skip-tag, --shard-index, and
+--shard-count flags have the same meaning as when running tests, as do the
+flags that narrow the config cases, like --protocol and --codec.
+
+With the --json flag, each test case is instead printed as a JSON object on
+its own line that also describes the configuration of the test case and the
+server instance against which it would be run.
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(flagset)
+		},
+	}
+	cmd.Flags().StringVar(&flagset.mode, modeFlagName, "",
+		"required: the mode of the test to list; must be 'client', 'server', or 'both'")
+	cmd.Flags().StringVar(&flagset.configFile, configFlagName, "",
+		"a config file in YAML format with supported features")
+	cmd.Flags().StringArrayVar(&flagset.testFiles, testFileFlagName, nil,
+		"a file in YAML format containing tests to list, which will skip the embedded tests; can be specified more than once")
+	cmd.Flags().StringArrayVar(&flagset.runPatterns, runFlagName, nil,
+		"a pattern indicating the name of test cases to list; when absent, all tests are listed (other than indicated by --skip); can be specified more than once")
+	cmd.Flags().StringArrayVar(&flagset.skipPatterns, skipFlagName, nil,
+		"a pattern indicating the name of test cases to omit; when absent, no tests are omitted; can be specified more than once")
+	cmd.Flags().StringSliceVar(&flagset.tags, tagFlagName, nil,
+		"a tag of test cases to list; when present, only test cases with at least one of the given tags are listed; can be specified more than once or as a comma-separated list")
+	cmd.Flags().StringSliceVar(&flagset.skipTags, skipTagFlagName, nil,
+		"a tag of test cases to omit; test cases with any of the given tags are not listed; can be specified more than once or as a comma-separated list")
+	bindDimensions(cmd.Flags(), &flagset.dimensions)
+	cmd.Flags().BoolVar(&flagset.json, jsonFlagName, false,
+		"if true, each test case is printed as a JSON object that includes its configuration")
+	cmd.Flags().UintVar(&flagset.shardIndex, shardIndexFlagName, 0,
+		"the zero-based index of the shard of test cases to list; used with --shard-count")
+	cmd.Flags().UintVar(&flagset.shardCount, shardCountFlagName, 1,
+		"the total number of shards across which test cases are split")
+	return cmd
+}
+
+func runList(flags *listFlags) {
+	fatal := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+		os.Exit(1)
+	}
+
+	switch flags.mode {
+	case "client", "server", "both":
+	default:
+		fatal(`Invalid mode: expecting "client", "server", or "both"; got %q`, flags.mode)
+	}
+
+	runPatterns, err := argsToPatterns(flags.runPatterns)
+	if err != nil {
+		fatal("%s", err)
+	}
+	skipPatterns, err := argsToPatterns(flags.skipPatterns)
+	if err != nil {
+		fatal("%s", err)
+	}
+
+	err = connectconformance.List(
+		&connectconformance.ListFlags{
+			Mode:         flags.mode,
+			ConfigFile:   flags.configFile,
+			TestFiles:    flags.testFiles,
+			RunPatterns:  runPatterns,
+			SkipPatterns: skipPatterns,
+			Tags:         flags.tags,
+			SkipTags:     flags.skipTags,
+			Dimensions:   flags.dimensions,
+			JSON:         flags.json,
+			ShardIndex:   flags.shardIndex,
+			ShardCount:   flags.shardCount,
+		},
+		internal.NewPrinter(os.Stdout),
+	)
+	if err != nil {
+		fatal("%s", err)
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"connectrpc.com/conformance/internal"
 	"connectrpc.com/conformance/internal/app/connectconformance"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -37,6 +38,14 @@ const (
 	knownFlakyFlagName    = "known-flaky"
 	runFlagName           = "run"
 	skipFlagName          = "skip"
+	tagFlagName           = "tag"
+	skipTagFlagName       = "skip-tag"
+	protocolFlagName      = "protocol"
+	httpVersionFlagName   = "http-version"
+	codecFlagName         = "codec"
+	compressionFlagName   = "compression"
+	streamTypeFlagName    = "stream-type"
+	tlsFlagName           = "tls"
 	verboseFlagName       = "verbose"
 	verboseFlagShortName  = "v"
 	veryVerboseFlagName   = "vv"
@@ -49,6 +58,29 @@ const (
 	portFlagName          = "port"
 	bindFlagName          = "bind"
 	traceFlagName         = "trace"
+	junitReportFlagName   = "junit-report"
+	jsonReportFlagName    = "json-report"
+	flakyRetriesFlagName  = "flaky-retries"
+	strictFlagName        = "strict"
+	failFastFlagName      = "fail-fast"
+	maxFailuresFlagName   = "max-failures"
+	writeKnownFailingFlag = "write-known-failing"
+	baselineFlagName      = "baseline"
+	stateFileFlagName     = "state-file"
+	rerunFailedFlagName   = "rerun-failed"
+	progressFlagName      = "progress"
+	serverHostFlagName    = "server-host"
+	serverPortFlagName    = "server-port"
+	serverCAFlagName      = "server-ca"
+	serverProtocolFlag    = "server-protocol"
+	serverHTTPVersionFlag = "server-http-version"
+	clientControlFlagName = "client-control-addr"
+	clientOriginFlagName  = "client-control-origin"
+	handshakeFlagName     = "handshake"
+	clientStdioFlagName   = "client-stdio-format"
+	serverStdioFlagName   = "server-stdio-format"
+	shardIndexFlagName    = "shard-index"
+	shardCountFlagName    = "shard-count"
 )
 
 type flags struct {
@@ -57,6 +89,9 @@ type flags struct {
 	testFiles            []string
 	runPatterns          []string
 	skipPatterns         []string
+	tags                 []string
+	skipTags             []string
+	dimensions           connectconformance.DimensionFlags
 	knownFailingPatterns []string
 	knownFlakyPatterns   []string
 	verbose              bool
@@ -69,18 +104,42 @@ type flags struct {
 	port                 uint
 	bind                 string
 	trace                bool
+	junitReportFile      string
+	jsonReportFile       string
+	flakyRetries         uint
+	strict               bool
+	failFast             bool
+	maxFailures          uint
+	writeKnownFailing    string
+	baselineFile         string
+	stateFile            string
+	rerunFailed          bool
+	progress             string
+	externalServer       connectconformance.ExternalServer
+	clientControlAddr    string
+	clientControlOrigin  string
+	handshake            bool
+	clientStdioFormat    string
+	serverStdioFormat    string
+	shardIndex           uint
+	shardCount           uint
 }
 
 func main() {
 	flagset := &flags{}
 	rootCmd := &cobra.Command{
 		Use: `connectconformance --mode [client|server] -- command...
-  connectconformance --mode both -- client-command... ---- server-command...`,
+  connectconformance --mode both -- client-command... ---- server-command...
+  connectconformance --mode server --server-port port
+  connectconformance --mode client --client-control-addr addr [-- command...]`,
 		Short: "Runs conformance tests against the given command.",
 		Long: `Runs conformance tests against a Connect implementation. Depending on the mode,
 the given command must be either a conformance client or a conformance server.
 When mode is both, two commands are given, separated by a quadruple-slash ("----"),
-with the client command being first and the server command second.
+with the client command being first and the server command second. In this mode,
+the client under test is run against the server under test, and each is also run
+against the reference implementation of the other, in the same invocation. The
+output then includes an interop matrix that shows which pairings passed.
 
 A conformance client tests a client implementation: the command reads test cases
 from stdin. Each test case describes an RPC to make. The command then records
@@ -121,12 +180,97 @@ failures are fixed. All of these flags support reading the list of test case
 patterns from a file using the "@" prefix. So a flag value with this prefix
 should be the path to a text file, which contains names or patterns, one per
 line.
+
+The --protocol, --http-version, --codec, --compression, --stream-type, and --tls
+flags narrow the config cases that are tested, without having to write a config
+file just for that. Each can be given more than once, in which case config cases
+that match any of the given values are tested.
+
+Test suites and test cases may also have tags, which categorize them by area,
+such as "cancellation" or "trailers". The --tag and --skip-tag flags select or
+omit test cases by tag, and they can be combined with --run and --skip. A
+known-failing or known-flaky entry of the form "tag:<name>" matches all test
+cases with the named tag.
+
+When something fundamental is broken, a run can produce a very large number
+of failures. The --fail-fast and --max-failures flags stop the run once the
+given number of test cases have failed. A known failure that unexpectedly
+passes counts as a failure. Test cases that were already sent are allowed to
+complete, but the rest are skipped.
+
+If a state file is given with --state-file, the names of the test cases that
+failed are recorded in it after each run. The --rerun-failed flag runs only the
+test cases named in that file, which makes it quick to check fixes for failures
+found by previous runs. The summary then also shows how many of the previously failing
+test cases now pass.
+
+Some test cases and some feedback from the reference client and server only
+check recommended behavior (a "SHOULD" in the protocol specifications) instead
+of required behavior (a "MUST"). Failures of these are reported as warnings,
+in a separate section of the output, and do not cause the run to fail. Use the
+--strict flag to treat them as failures instead.
+
+The --junit-report flag may be used to also write the results to a file in
+JUnit XML format, which many CI systems can ingest. Test cases that fail but
+are known to be failing or flaky are reported as skipped. Similarly, the
+--json-report flag writes the results to a file in JSON-lines format, with
+each line describing the outcome and configuration of one test case.
+
+While tests are running, their progress is shown on stderr, as a status line
+that is updated in place when stderr is a terminal, or as a line that is logged
+every 30 seconds otherwise. Use the --progress flag to change this.
+
+In server mode, the server under test may already be running, instead of being
+started by this program. In that case, no positional arguments are given, and
+the --server-port flag (along with --server-host and, if the server uses TLS,
+--server-ca) indicates where it is running. The --server-protocol and
+--server-http-version flags describe the configurations that the server supports,
+and only test cases that are compatible with them are run.
+
+In client mode, a client that can't use stdin and stdout, like one that runs in
+a browser, can instead be driven over HTTP using the --client-control-addr flag.
+The test runner then serves a control channel at the given address: the client
+repeatedly sends a GET request to the "/next" path for the next test case and
+sends a POST request to the "/result" path with the result of each. These use
+the same message types as above, without any length prefix, in the Protobuf
+binary format or, if requested via the Accept and Content-Type headers, in the
+Protobuf JSON format. A GET request responds with "204 No Content" if no test
+case is available yet, in which case the client should try again, and with
+"410 Gone" when there are no more test cases, in which case the client should
+exit. If a command is given, it is started with the URL of the control channel
+in the CONNECTCONFORMANCE_CONTROL_URL environment variable. Otherwise, the
+client must be started separately and pointed at the URL that is printed. In
+both mode, the client command before the "----" is then optional. A client that
+runs in a page served from another origin also needs the --client-control-origin
+flag, so that the control channel allows cross-origin requests from it.
+
+In client or server mode, the --handshake flag lets the implementation under test
+report the features it supports, instead of (or in addition to) describing them
+in the config file. The command is first started with the environment variable
+CONNECTCONFORMANCE_HANDSHAKE set to "1". Before processing any requests, it must
+then write a connectrpc.conformance.v1.Config message to stdout, in the same
+format as the other messages. Its stdin is closed, since there are no requests,
+so it should then exit. The reported config is merged with the config file, if
+one is given. When both set the same field, the value from the config file is
+used, and a warning is printed if they disagree.
+
+The "list" sub-command can be used to print the names of the test cases that
+would be run, without actually running them. And the "explain" sub-command
+describes why test suites or cases are or are not run for a given config. The
+"validate-config" sub-command checks config files for problems without running
+any tests, and the "lint-suites" sub-command checks test suite definitions.
 `,
+		// The positional args are the command under test, not a sub-command.
+		Args: cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			run(flagset, cmd.Flags(), args)
 		},
 	}
 	bind(rootCmd, flagset)
+	rootCmd.AddCommand(newListCommand())
+	rootCmd.AddCommand(newExplainCommand())
+	rootCmd.AddCommand(newValidateConfigCommand())
+	rootCmd.AddCommand(newLintSuitesCommand())
 	_ = rootCmd.Execute()
 }
 
@@ -141,10 +285,29 @@ func bind(cmd *cobra.Command, flags *flags) {
 		"a pattern indicating the name of test cases to run; when absent, all tests are run (other than indicated by --skip); can be specified more than once")
 	cmd.Flags().StringArrayVar(&flags.skipPatterns, skipFlagName, nil,
 		"a pattern indicating the name of test cases to skip; when absent, no tests are skipped; can be specified more than once")
+	cmd.Flags().StringSliceVar(&flags.tags, tagFlagName, nil,
+		"a tag of test cases to run; when present, only test cases with at least one of the given tags are run; can be specified more than once or as a comma-separated list")
+	cmd.Flags().StringSliceVar(&flags.skipTags, skipTagFlagName, nil,
+		"a tag of test cases to skip; test cases with any of the given tags are not run; can be specified more than once or as a comma-separated list")
+	bindDimensions(cmd.Flags(), &flags.dimensions)
 	cmd.Flags().StringArrayVar(&flags.knownFailingPatterns, knownFailingFlagName, nil,
 		"a pattern indicating the name of test cases that are known to fail; these test cases will be required to fail for the run to be successful; can be specified more than once")
 	cmd.Flags().StringArrayVar(&flags.knownFlakyPatterns, knownFlakyFlagName, nil,
 		"a pattern indicating the name of test cases that are flaky; these test cases are allowed (but not required) to fail; can be specified more than once")
+	cmd.Flags().UintVar(&flags.flakyRetries, flakyRetriesFlagName, 0,
+		"the number of times a known flaky test case is retried when it fails; it is only considered an expected failure if all attempts fail")
+	cmd.Flags().BoolVar(&flags.failFast, failFastFlagName, false,
+		"stop running test cases after the first failure; same as --max-failures=1")
+	cmd.Flags().UintVar(&flags.maxFailures, maxFailuresFlagName, 0,
+		"stop running test cases after this many have failed; test cases that were already sent are allowed to finish, and the rest are skipped; zero means no limit")
+	cmd.Flags().BoolVar(&flags.strict, strictFlagName, false,
+		"if true, failures of recommended (SHOULD-level) behavior are treated as failures instead of warnings")
+	cmd.Flags().StringVar(&flags.stateFile, stateFileFlagName, "",
+		"a file path in which the names of failing test cases are kept, for use with --rerun-failed; it is updated after each run, and test cases that did not run keep their previous entries")
+	cmd.Flags().BoolVar(&flags.rerunFailed, rerunFailedFlagName, false,
+		"only run the test cases that failed in the previous run, as recorded in the state file")
+	cmd.Flags().StringVar(&flags.writeKnownFailing, writeKnownFailingFlag, "",
+		"a file path to which patterns that match all currently failing test cases will be written, for use with --known-failing; comments in an existing file are preserved")
 	cmd.Flags().BoolVarP(&flags.verbose, verboseFlagName, verboseFlagShortName, false,
 		"enables verbose output")
 	cmd.Flags().BoolVar(&flags.veryVerbose, veryVerboseFlagName, false,
@@ -155,6 +318,12 @@ func bind(cmd *cobra.Command, flags *flags) {
 		"the maximum number of server processes to be running in parallel")
 	cmd.Flags().UintVarP(&flags.parallel, parallelFlagName, parallelFlagShortName, uint(runtime.GOMAXPROCS(0)*4),
 		"in server mode, the level of parallelism used when issuing RPCs")
+	cmd.Flags().StringVar(&flags.progress, progressFlagName, "auto",
+		"how to show the progress of the run on stderr: 'terminal' updates a status line in place, 'log' periodically logs a status line, and 'off' shows nothing; 'auto' uses 'terminal' if stderr is a terminal and 'log' otherwise")
+	cmd.Flags().UintVar(&flags.shardIndex, shardIndexFlagName, 0,
+		"the zero-based index of the shard of test cases to run; used with --shard-count to split test cases across multiple runs")
+	cmd.Flags().UintVar(&flags.shardCount, shardCountFlagName, 1,
+		"the total number of shards across which test cases are split; each run should use a different --shard-index")
 	cmd.Flags().StringVar(&flags.tlsCertFile, tlsCertFlagName, "",
 		"in client mode, the path to a PEM-encoded TLS certificate file that the reference server should use")
 	cmd.Flags().StringVar(&flags.tlsKeyFile, tlsKeyFlagName, "",
@@ -163,8 +332,51 @@ func bind(cmd *cobra.Command, flags *flags) {
 		"in client mode, the port number on which the reference server should listen (implies --max-servers=1)")
 	cmd.Flags().StringVar(&flags.bind, bindFlagName, internal.DefaultHost,
 		"in client mode, the bind address on which the reference server should listen (0.0.0.0 means listen on all interfaces)")
+	cmd.Flags().StringVar(&flags.externalServer.Host, serverHostFlagName, internal.DefaultHost,
+		"in server mode, the host of an already-running server to test (used with --server-port)")
+	cmd.Flags().Uint32Var(&flags.externalServer.Port, serverPortFlagName, 0,
+		"in server mode, the port of an already-running server to test; when specified, no server command is given")
+	cmd.Flags().StringVar(&flags.externalServer.CACertFile, serverCAFlagName, "",
+		"in server mode, the path to a PEM-encoded CA certificate for verifying the already-running server; when specified, the server is assumed to use TLS")
+	cmd.Flags().StringSliceVar(&flags.externalServer.Protocols, serverProtocolFlag, nil,
+		"a protocol supported by the already-running server: 'connect', 'grpc', or 'grpc-web'; when absent, all protocols are assumed to be supported")
+	cmd.Flags().StringSliceVar(&flags.externalServer.HTTPVersions, serverHTTPVersionFlag, nil,
+		"an HTTP version supported by the already-running server: '1', '2', or '3'; when absent, all HTTP versions are assumed to be supported")
+	cmd.Flags().StringVar(&flags.clientControlAddr, clientControlFlagName, "",
+		"in client or both mode, the address (host:port) at which to serve an HTTP control channel through which the client under test receives test cases and reports results, instead of using stdin and stdout; port zero picks any available port")
+	cmd.Flags().StringVar(&flags.clientControlOrigin, clientOriginFlagName, "",
+		"the origin from which a client running in a browser may use the control channel (used with --client-control-addr); '*' allows any origin")
+	cmd.Flags().BoolVar(&flags.handshake, handshakeFlagName, false,
+		"in client or server mode, if true, the implementation under test is first started just to report the config it supports, which is merged with the config file")
+	cmd.Flags().StringVar(&flags.clientStdioFormat, clientStdioFlagName, "",
+		"in client or both mode, the format of the messages exchanged with the client under test: 'binary' or 'json'; when absent, the stdio_format in the config file is used")
+	cmd.Flags().StringVar(&flags.serverStdioFormat, serverStdioFlagName, "",
+		"in server or both mode, the format of the messages exchanged with the server under test: 'binary' or 'json'; when absent, the stdio_format in the config file is used")
 	cmd.Flags().BoolVar(&flags.trace, traceFlagName, false,
 		"if true, full HTTP traces will be captured and shown alongside failing test cases")
+	cmd.Flags().StringVar(&flags.junitReportFile, junitReportFlagName, "",
+		"a file path to which a JUnit XML report of the test results will be written")
+	cmd.Flags().StringVar(&flags.jsonReportFile, jsonReportFlagName, "",
+		"a file path to which the test results will be written as JSON, one line per test case")
+	cmd.Flags().StringVar(&flags.baselineFile, baselineFlagName, "",
+		"a JSON results file from a previous run (see --json-report) with which to compare results; only test cases that newly fail will cause the run to fail")
+}
+
+// bindDimensions defines the flags that narrow the config cases that are
+// tested to those with particular values for their dimensions.
+func bindDimensions(flagSet *pflag.FlagSet, dimensions *connectconformance.DimensionFlags) {
+	flagSet.StringSliceVar(&dimensions.Protocols, protocolFlagName, nil,
+		"only test config cases with the given protocol: 'connect', 'grpc', or 'grpc-web'; can be specified more than once or as a comma-separated list")
+	flagSet.StringSliceVar(&dimensions.HTTPVersions, httpVersionFlagName, nil,
+		"only test config cases with the given HTTP version: '1', '2', or '3'; can be specified more than once or as a comma-separated list")
+	flagSet.StringSliceVar(&dimensions.Codecs, codecFlagName, nil,
+		"only test config cases with the given codec: 'proto', 'json', or 'text'; can be specified more than once or as a comma-separated list")
+	flagSet.StringSliceVar(&dimensions.Compressions, compressionFlagName, nil,
+		"only test config cases with the given compression, such as 'identity' or 'gzip'; can be specified more than once or as a comma-separated list")
+	flagSet.StringSliceVar(&dimensions.StreamTypes, streamTypeFlagName, nil,
+		"only test config cases with the given stream type, such as 'unary' or 'server-stream'; can be specified more than once or as a comma-separated list")
+	flagSet.StringSliceVar(&dimensions.TLS, tlsFlagName, nil,
+		"only test config cases with ('true') or without ('false') TLS; can be specified more than once or as a comma-separated list")
 }
 
 func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:gocyclo
@@ -178,8 +390,42 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		os.Exit(1)
 	}
 
-	if len(command) == 0 {
-		fatal(`Positional arguments are required to configure the command line of the client or server under test.`)
+	useExternalServer := cobraFlags.Changed(serverPortFlagName)
+	if useExternalServer {
+		if flags.mode != "server" {
+			fatal(`Cannot specify --%s flag when mode is %s`, serverPortFlagName, flags.mode)
+		}
+		if len(command) > 0 {
+			fatal(`Positional arguments are not allowed when testing an already-running server with --%s.`, serverPortFlagName)
+		}
+		if flags.externalServer.Port == 0 {
+			fatal(`Invalid server port: must be greater than zero`)
+		}
+	} else {
+		for _, name := range []string{serverHostFlagName, serverCAFlagName, serverProtocolFlag, serverHTTPVersionFlag} {
+			if cobraFlags.Changed(name) {
+				fatal(`Cannot specify --%s flag without --%s`, name, serverPortFlagName)
+			}
+		}
+		if len(command) == 0 && flags.clientControlAddr == "" {
+			fatal(`Positional arguments are required to configure the command line of the client or server under test.`)
+		}
+	}
+	if flags.clientControlAddr != "" && flags.mode != "client" && flags.mode != "both" {
+		fatal(`Cannot specify --%s flag when mode is %s`, clientControlFlagName, flags.mode)
+	}
+	if flags.clientControlOrigin != "" && flags.clientControlAddr == "" {
+		fatal(`Cannot specify --%s flag without --%s`, clientOriginFlagName, clientControlFlagName)
+	}
+	if flags.handshake {
+		switch {
+		case flags.mode != "client" && flags.mode != "server":
+			fatal(`Cannot specify --%s flag when mode is %s`, handshakeFlagName, flags.mode)
+		case useExternalServer:
+			fatal(`Cannot specify --%s flag with --%s`, handshakeFlagName, serverPortFlagName)
+		case flags.clientControlAddr != "":
+			fatal(`Cannot specify --%s flag with --%s`, handshakeFlagName, clientControlFlagName)
+		}
 	}
 
 	if flags.maxServers == 0 {
@@ -195,6 +441,73 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 	if flags.parallel == 0 {
 		fatal(`Invalid parallelism: must be greater than zero`)
 	}
+	if flags.failFast {
+		if flags.maxFailures > 1 {
+			fatal(`Cannot specify both --%s and --%s greater than one`, failFastFlagName, maxFailuresFlagName)
+		}
+		flags.maxFailures = 1
+	}
+	if flags.maxFailures > 0 && flags.writeKnownFailing != "" {
+		// Test cases that are skipped might be failing, too.
+		fatal(`Cannot specify --%s when the number of failures is limited`, writeKnownFailingFlag)
+	}
+	if flags.writeKnownFailing != "" {
+		// Test cases that are not run might be passing, but could still be
+		// matched by wildcard patterns computed from the ones that were run.
+		for _, name := range []string{
+			runFlagName, skipFlagName, tagFlagName, skipTagFlagName,
+			protocolFlagName, httpVersionFlagName, codecFlagName, compressionFlagName, streamTypeFlagName, tlsFlagName,
+		} {
+			if cobraFlags.Changed(name) {
+				fatal(`Cannot specify --%s when test cases are filtered with --%s`, writeKnownFailingFlag, name)
+			}
+		}
+		if flags.rerunFailed {
+			fatal(`Cannot specify --%s with --%s`, writeKnownFailingFlag, rerunFailedFlagName)
+		}
+		if flags.shardCount > 1 {
+			fatal(`Cannot specify --%s when test cases are sharded`, writeKnownFailingFlag)
+		}
+	}
+
+	if flags.rerunFailed {
+		if flags.stateFile == "" {
+			fatal(`Cannot specify --%s flag without --%s`, rerunFailedFlagName, stateFileFlagName)
+		}
+		if len(flags.runPatterns) > 0 {
+			fatal(`Cannot specify both --%s and --%s`, rerunFailedFlagName, runFlagName)
+		}
+	}
+
+	progress, ok := progressMode(flags.progress)
+	if !ok {
+		fatal(`Invalid progress: expecting "auto", "terminal", "log", or "off"; got %q`, flags.progress)
+	}
+
+	clientStdioFormat, ok := stdioFormat(flags.clientStdioFormat)
+	if !ok {
+		fatal(`Invalid client stdio format: expecting "binary" or "json"; got %q`, flags.clientStdioFormat)
+	}
+	serverStdioFormat, ok := stdioFormat(flags.serverStdioFormat)
+	if !ok {
+		fatal(`Invalid server stdio format: expecting "binary" or "json"; got %q`, flags.serverStdioFormat)
+	}
+	if flags.clientStdioFormat != "" {
+		switch {
+		case flags.mode != "client" && flags.mode != "both":
+			fatal(`Cannot specify --%s flag when mode is %s`, clientStdioFlagName, flags.mode)
+		case flags.clientControlAddr != "":
+			fatal(`Cannot specify --%s flag with --%s`, clientStdioFlagName, clientControlFlagName)
+		}
+	}
+	if flags.serverStdioFormat != "" {
+		switch {
+		case flags.mode != "server" && flags.mode != "both":
+			fatal(`Cannot specify --%s flag when mode is %s`, serverStdioFlagName, flags.mode)
+		case useExternalServer:
+			fatal(`Cannot specify --%s flag with --%s`, serverStdioFlagName, serverPortFlagName)
+		}
+	}
 
 	var clientCommand, serverCommand []string
 	switch flags.mode {
@@ -209,15 +522,13 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		}
 		clientCommand = command[:pos]
 		serverCommand = command[pos+1:]
-		if len(clientCommand) == 0 {
+		if len(clientCommand) == 0 && flags.clientControlAddr == "" {
 			fatal(`Client command (before the "----") is empty.`)
 		}
 		if len(serverCommand) == 0 {
 			fatal(`Server command (after the "----") is empty.`)
 		}
 	default:
-		// TODO: support mode "both", which would allow the caller to supply both the
-		//       client and server commands, instead of using a reference impl?
 		fatal(`Invalid mode: expecting "client", "server", or "both"; got %q`, flags.mode)
 	}
 
@@ -289,11 +600,19 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		fatal("%s", err)
 	}
 
-	ok, err := connectconformance.Run(
+	var externalServer *connectconformance.ExternalServer
+	if useExternalServer {
+		externalServer = &flags.externalServer
+	}
+
+	ok, err = connectconformance.Run(
 		&connectconformance.Flags{
 			ConfigFile:           flags.configFile,
 			RunPatterns:          runPatterns,
 			SkipPatterns:         skipPatterns,
+			Tags:                 flags.tags,
+			SkipTags:             flags.skipTags,
+			Dimensions:           flags.dimensions,
 			KnownFailingPatterns: knownFailingPatterns,
 			KnownFlakyPatterns:   knownFlakyPatterns,
 			TestFiles:            flags.testFiles,
@@ -308,6 +627,25 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 			ServerPort:           flags.port,
 			ServerBind:           flags.bind,
 			HTTPTrace:            flags.trace,
+			JUnitReportFile:      flags.junitReportFile,
+			JSONReportFile:       flags.jsonReportFile,
+			FlakyRetries:         flags.flakyRetries,
+			Strict:               flags.strict,
+			MaxFailures:          flags.maxFailures,
+			KnownFailingOutFile:  flags.writeKnownFailing,
+			StateFile:            flags.stateFile,
+			RerunFailed:          flags.rerunFailed,
+			BaselineFile:         flags.baselineFile,
+			ShardIndex:           flags.shardIndex,
+			ShardCount:           flags.shardCount,
+			Progress:             progress,
+			ProgressWriter:       os.Stderr,
+			ExternalServer:       externalServer,
+			ClientControlAddr:    flags.clientControlAddr,
+			ClientControlOrigin:  flags.clientControlOrigin,
+			Handshake:            flags.handshake,
+			ClientStdioFormat:    clientStdioFormat,
+			ServerStdioFormat:    serverStdioFormat,
 		},
 		internal.NewPrinter(os.Stdout),
 		internal.NewPrinter(os.Stderr),
@@ -320,6 +658,51 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 	}
 }
 
+// progressMode parses the value of the --progress flag. It returns false
+// if the value is not valid.
+func progressMode(value string) (connectconformance.ProgressMode, bool) {
+	switch value {
+	case "auto":
+		if isTerminal(os.Stderr) {
+			return connectconformance.ProgressTerminal, true
+		}
+		return connectconformance.ProgressLog, true
+	case "terminal":
+		return connectconformance.ProgressTerminal, true
+	case "log":
+		return connectconformance.ProgressLog, true
+	case "off":
+		return connectconformance.ProgressOff, true
+	default:
+		return connectconformance.ProgressOff, false
+	}
+}
+
+// stdioFormat parses the value of the --client-stdio-format or
+// --server-stdio-format flag. It returns false if the value is invalid.
+func stdioFormat(value string) (conformancev1.StdioFormat, bool) {
+	switch value {
+	case "":
+		return conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED, true
+	case "binary":
+		return conformancev1.StdioFormat_STDIO_FORMAT_BINARY, true
+	case "json":
+		return conformancev1.StdioFormat_STDIO_FORMAT_JSON, true
+	default:
+		return conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED, false
+	}
+}
+
+// isTerminal returns true if the given file is a terminal (as opposed
+// to a pipe or regular file).
+func isTerminal(file *os.File) bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 func positionOf(slice []string, item string) int {
 	for i, str := range slice {
 		if str == item {
@@ -0,0 +1,51 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance"
+	"github.com/spf13/cobra"
+)
+
+func newValidateConfigCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-config config.yaml...",
+		Short: "Checks the given config files for problems.",
+		Long: `Checks the given config files, in YAML format, for problems without running any
+tests. This reports impossible or contradictory combinations of features (such
+as supporting TLS client certs but not TLS, or the gRPC protocol without HTTP/2
+or without trailers), invalid include and exclude cases, and include and exclude
+cases that match nothing. Each problem is reported along with the line in the
+file that caused it.
+
+The same validation is done when running tests with a --conf file.
+`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ok, err := connectconformance.ValidateConfig(args, internal.NewPrinter(os.Stdout))
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(1)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+}
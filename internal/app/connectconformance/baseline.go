@@ -0,0 +1,158 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// baselineComparison describes the differences between the results of
+// the current run and those of a previous run.
+type baselineComparison struct {
+	// Test cases that passed in the baseline but now fail, as well as
+	// test cases that are not in the baseline and unexpectedly fail.
+	newFailures []string
+	// Test cases that failed in the baseline but now pass.
+	newPasses []string
+	// Test cases that were run but are not in the baseline, other than
+	// those in newFailures.
+	appeared []string
+	// Test cases that are in the baseline but were not run.
+	disappeared []string
+	// If true, only some test cases were selected to run, so baseline
+	// test cases that were not run are not reported as removed.
+	filtered bool
+}
+
+// readBaseline reads the named baseline file, which is a JSON results file
+// that was written by a previous run (see writeJSONReport). The returned map
+// is keyed by test case name.
+func readBaseline(fileName string) (map[string]*resultRecord, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	baseline, err := parseBaseline(file)
+	if err != nil {
+		return nil, internal.EnsureFileName(err, fileName)
+	}
+	return baseline, nil
+}
+
+func parseBaseline(reader io.Reader) (map[string]*resultRecord, error) {
+	baseline := map[string]*resultRecord{}
+	dec := json.NewDecoder(reader)
+	for {
+		var record resultRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return baseline, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse baseline: %w", err)
+		}
+		if record.Name == "" {
+			return nil, errors.New("failed to parse baseline: record is missing test case name")
+		}
+		baseline[record.Name] = &record
+	}
+}
+
+// failed returns true if the given record represents a failed test case,
+// regardless of whether that failure was expected. Warnings are not
+// considered failures.
+func (rec *resultRecord) failed() bool {
+	if rec.Status == statusWarning.String() {
+		return false
+	}
+	return rec.Error != "" || rec.Status == statusFailed.String() || rec.Status == statusExpectedFailure.String()
+}
+
+// compareToBaseline compares the outcomes in these results to the given
+// baseline and returns the differences. Test cases that are not in the
+// baseline count as new failures if they fail and are not known to fail.
+// Baseline test cases that did not
+// run are only reported as removed if all test cases were selected and
+// the run was not stopped early.
+func (r *testResults) compareToBaseline(baseline map[string]*resultRecord) *baselineComparison {
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+
+	comparison := baselineComparison{filtered: r.filtered}
+	for name, outcome := range r.outcomes {
+		prev, ok := baseline[name]
+		switch {
+		case !ok && outcome.status() == statusFailed:
+			comparison.newFailures = append(comparison.newFailures, name)
+		case !ok:
+			comparison.appeared = append(comparison.appeared, name)
+		case outcome.actualFailure != nil && !outcome.warning && !prev.failed():
+			comparison.newFailures = append(comparison.newFailures, name)
+		case (outcome.actualFailure == nil || outcome.warning) && prev.failed():
+			comparison.newPasses = append(comparison.newPasses, name)
+		}
+	}
+	for name := range baseline {
+		if _, ok := r.outcomes[name]; ok {
+			continue
+		}
+		if _, ok := r.skipped[name]; ok {
+			continue
+		}
+		if comparison.filtered {
+			// The test case may have been filtered out, rather than removed.
+			continue
+		}
+		comparison.disappeared = append(comparison.disappeared, name)
+	}
+	sort.Strings(comparison.newFailures)
+	sort.Strings(comparison.newPasses)
+	sort.Strings(comparison.appeared)
+	sort.Strings(comparison.disappeared)
+	return &comparison
+}
+
+// report prints the differences to the given printer. It returns true if
+// there are no new failures.
+func (c *baselineComparison) report(printer internal.Printer) bool {
+	printer.Printf("\nCompared to baseline:")
+	printNames := func(what string, names []string) {
+		printer.Printf("%d %s", len(names), what)
+		for _, name := range names {
+			printer.Printf("  %s", name)
+		}
+	}
+	printNames("new failure(s)", c.newFailures)
+	printNames("new pass(es)", c.newPasses)
+	printNames("new test case(s)", c.appeared)
+	if c.filtered {
+		printer.Printf("(removed test cases are not reported, since test cases were filtered or sharded)")
+	} else {
+		printNames("removed test case(s)", c.disappeared)
+	}
+	return len(c.newFailures) == 0
+}
@@ -0,0 +1,109 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_CompareToBaseline(t *testing.T) {
+	t.Parallel()
+	previous := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	previous.setOutcome("foo/still-passes", false, nil)
+	previous.setOutcome("foo/still-fails", false, errors.New("ruh roh"))
+	previous.setOutcome("foo/now-fails", false, nil)
+	previous.setOutcome("foo/now-passes", false, errors.New("ruh roh"))
+	previous.setOutcome("known-to-fail/now-passes", false, errors.New("ruh roh"))
+	previous.setOutcome("foo/removed", false, nil)
+	var buf bytes.Buffer
+	err := previous.writeJSON(&buf)
+	require.NoError(t, err)
+	baseline, err := parseBaseline(&buf)
+	require.NoError(t, err)
+	require.Len(t, baseline, 6)
+
+	current := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	current.setOutcome("foo/still-passes", false, nil)
+	current.setOutcome("foo/still-fails", false, errors.New("ruh roh"))
+	current.setOutcome("foo/now-fails", false, errors.New("ruh roh"))
+	current.setOutcome("foo/now-passes", false, nil)
+	current.setOutcome("known-to-fail/now-passes", false, nil)
+	current.setOutcome("foo/added", false, nil)
+	current.setOutcome("foo/added-fails", false, errors.New("ruh roh"))
+	current.setOutcome("known-to-fail/added", false, errors.New("ruh roh"))
+	comparison := current.compareToBaseline(baseline)
+	assert.Equal(t, &baselineComparison{
+		newFailures: []string{"foo/added-fails", "foo/now-fails"},
+		newPasses:   []string{"foo/now-passes", "known-to-fail/now-passes"},
+		appeared:    []string{"foo/added", "known-to-fail/added"},
+		disappeared: []string{"foo/removed"},
+	}, comparison)
+
+	printer := &internal.SimplePrinter{}
+	require.False(t, comparison.report(printer))
+	assert.Equal(t, []string{
+		"\nCompared to baseline:\n",
+		"2 new failure(s)\n",
+		"  foo/added-fails\n",
+		"  foo/now-fails\n",
+		"2 new pass(es)\n",
+		"  foo/now-passes\n",
+		"  known-to-fail/now-passes\n",
+		"2 new test case(s)\n",
+		"  foo/added\n",
+		"  known-to-fail/added\n",
+		"1 removed test case(s)\n",
+		"  foo/removed\n",
+	}, printer.Messages)
+
+	// When test cases are filtered, those that didn't run may not have
+	// been removed, so they are not reported.
+	current.filtered = true
+	printer = &internal.SimplePrinter{}
+	comparison = current.compareToBaseline(baseline)
+	assert.Empty(t, comparison.disappeared)
+	require.False(t, comparison.report(printer))
+	assert.Equal(t, "(removed test cases are not reported, since test cases were filtered or sharded)\n", printer.Messages[len(printer.Messages)-1])
+	current.filtered = false
+
+	// Test cases skipped after too many failures were not removed.
+	current.skipped = map[string]struct{}{"foo/removed": {}}
+	assert.Empty(t, current.compareToBaseline(baseline).disappeared)
+	current.skipped = nil
+
+	// A new test case that fails is a new failure, even if it is
+	// the only difference.
+	current.setOutcome("foo/now-fails", false, nil)
+	require.False(t, current.compareToBaseline(baseline).report(&internal.SimplePrinter{}))
+
+	// Without new failures, comparison succeeds.
+	current.setOutcome("foo/added-fails", false, nil)
+	require.True(t, current.compareToBaseline(baseline).report(&internal.SimplePrinter{}))
+}
+
+func TestParseBaseline_Invalid(t *testing.T) {
+	t.Parallel()
+	_, err := parseBaseline(strings.NewReader(`{"name": "foo/bar"}` + "\n" + `{"status": "passed"}`))
+	require.ErrorContains(t, err, "missing test case name")
+	_, err = parseBaseline(strings.NewReader(`{"name": "foo/bar"`))
+	require.ErrorContains(t, err, "failed to parse baseline")
+}
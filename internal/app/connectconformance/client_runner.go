@@ -41,13 +41,15 @@ type clientRunner interface {
 	stop()
 }
 
-func runClient(ctx context.Context, start processStarter) (clientRunner, error) {
+func runClient(ctx context.Context, start processStarter, codec internal.Codec) (clientRunner, error) {
 	proc, err := start(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 	result := &clientProcessRunner{
 		proc:       proc,
+		encoder:    codec.NewEncoder(proc.stdin),
+		decoder:    codec.NewDecoder(proc.stdout),
 		done:       make(chan struct{}),
 		pendingOps: map[string]func(string, *conformancev1.ClientCompatResponse, error){},
 	}
@@ -60,6 +62,8 @@ func runClient(ctx context.Context, start processStarter) (clientRunner, error)
 
 type clientProcessRunner struct {
 	proc       *process
+	encoder    internal.StreamEncoder // writes to proc.stdin; guarded by sendMu
+	decoder    internal.StreamDecoder // reads from proc.stdout; only used by consumeOutput
 	terminated atomic.Bool
 
 	err  atomic.Pointer[error]
@@ -104,7 +108,7 @@ func (c *clientProcessRunner) sendRequest(req *conformancev1.ClientCompatRequest
 		return fmt.Errorf("%w: %q", errDuplicate, req.TestName)
 	}
 
-	if err := internal.WriteDelimitedMessage(c.proc.stdin, req); err != nil {
+	if err := c.encoder.Encode(req); err != nil {
 		// Since we eagerly added to pending set but failed to write,
 		// we now need to remove it to clean up.
 		c.pendingMu.Lock()
@@ -205,7 +209,7 @@ func (c *clientProcessRunner) consumeOutput() {
 		readDone := make(chan struct{})
 		go func() {
 			defer close(readDone)
-			readErr = internal.ReadDelimitedMessage(c.proc.stdout, resp)
+			readErr = c.decoder.DecodeNext(resp)
 		}()
 
 		select {
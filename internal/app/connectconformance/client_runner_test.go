@@ -50,6 +50,7 @@ func TestRunClient(t *testing.T) {
 	testCases := []struct {
 		name            string
 		clientFunc      func(_ context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error
+		stdioFormat     conformancev1.StdioFormat
 		expectErr       string
 		failToSend      int
 		expectedResults map[string]bool
@@ -64,6 +65,17 @@ func TestRunClient(t *testing.T) {
 				"TestSuite2/testcase2": true,
 			},
 		},
+		{
+			name:        "json",
+			clientFunc:  (&testClientProcess{stdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_JSON}).run,
+			stdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_JSON,
+			expectedResults: map[string]bool{
+				"TestSuite1/testcase1": true,
+				"TestSuite1/testcase2": true,
+				"TestSuite2/testcase1": true,
+				"TestSuite2/testcase2": true,
+			},
+		},
 		{
 			name:       "client fails",
 			clientFunc: (&testClientProcess{failAfter: 2}).run,
@@ -102,7 +114,7 @@ func TestRunClient(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 			start := runInProcess([]string{"testclient"}, testCase.clientFunc)
-			runner, err := runClient(context.Background(), start)
+			runner, err := runClient(context.Background(), start, newStdioCodec(testCase.stdioFormat))
 			require.NoError(t, err)
 
 			actualResults := make(map[string]bool, len(testReqs))
@@ -136,14 +148,17 @@ func TestRunClient(t *testing.T) {
 
 // testClientProcess reads requests from in and immediately writes a corresponding response to out.
 type testClientProcess struct {
-	failAfter int
+	failAfter   int
+	stdioFormat conformancev1.StdioFormat
 }
 
 func (c *testClientProcess) run(_ context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error {
+	codec := newStdioCodec(c.stdioFormat)
+	decoder, encoder := codec.NewDecoder(in), codec.NewEncoder(out)
 	var count int
 	for {
 		req := &conformancev1.ClientCompatRequest{}
-		if err := internal.ReadDelimitedMessage(in, req); err != nil {
+		if err := decoder.DecodeNext(req); err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
@@ -159,7 +174,7 @@ func (c *testClientProcess) run(_ context.Context, _ []string, in io.ReadCloser,
 				},
 			},
 		}
-		if err := internal.WriteDelimitedMessage(out, resp); err != nil {
+		if err := encoder.Encode(resp); err != nil {
 			return err
 		}
 		count++
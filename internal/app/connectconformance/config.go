@@ -17,6 +17,7 @@ package connectconformance
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -60,49 +61,138 @@ type supportedFeatures struct {
 // parseConfig loads all config cases from the given file name. If the given
 // file data is empty, it returns all config cases based on default features.
 func parseConfig(configFileName string, data []byte) ([]configCase, error) {
-	var config conformancev1.Config
-	if len(data) > 0 {
-		opts := protoyaml.UnmarshalOptions{
-			Path: configFileName,
-		}
-		if err := opts.Unmarshal(data, &config); err != nil {
-			return nil, internal.EnsureFileName(err, configFileName)
-		}
+	config, err := unmarshalConfig(configFileName, data)
+	if err != nil {
+		return nil, err
+	}
+	return computeConfigCases(configFileName, data, config)
+}
+
+// parseConfigWithSelfReported is like parseConfig, except that the given
+// self-reported config is merged with the config from the file.
+func parseConfigWithSelfReported(configFileName string, data []byte, selfReported *selfReportedConfig, logPrinter internal.Printer) ([]configCase, error) {
+	config, err := unmarshalConfig(configFileName, data)
+	if err != nil {
+		return nil, err
+	}
+	config = selfReported.merge(configFileName, config, logPrinter)
+	if configFileName == "" {
+		// Any errors must be in the self-reported config.
+		configFileName = "config reported by the " + selfReported.reporter
+	}
+	return computeConfigCases(configFileName, data, config)
+}
+
+// computeConfigCases computes all config cases described by the given config.
+// The given file name and data are used to report the location of any errors.
+func computeConfigCases(configFileName string, data []byte, config *conformancev1.Config) ([]configCase, error) {
+	cases, _, err := resolveConfigMessage(configFileName, data, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("%s: configuration resulted in zero cases to test", configFileName)
+	}
+	casesSlice := make([]configCase, 0, len(cases))
+	for c := range cases {
+		casesSlice = append(casesSlice, c)
+	}
+	return casesSlice, nil
+}
+
+// resolveConfig computes the config cases described by the given config file
+// data. In addition to the resulting set of cases, it also returns the cases
+// that were removed by the config's exclude_cases, mapped to the (one-based)
+// index of the first exclude case that removed them.
+func resolveConfig(configFileName string, data []byte) (map[configCase]struct{}, map[configCase]int, error) {
+	config, err := unmarshalConfig(configFileName, data)
+	if err != nil {
+		return nil, nil, err
 	}
+	return resolveConfigMessage(configFileName, data, config)
+}
+
+// resolveConfigMessage is like resolveConfig, except that the config has
+// already been unmarshalled from the given data.
+func resolveConfigMessage(configFileName string, data []byte, config *conformancev1.Config) (map[configCase]struct{}, map[configCase]int, error) {
 	if config.Features == nil {
 		config.Features = &conformancev1.Features{}
 	}
 	features, err := resolveFeatures(config.Features)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", configFileName, err)
+		return nil, nil, locateConfigErrors(configFileName, data, prefixConfigErrors(err, "", "features"))
 	}
 	cases := computeCasesFromFeatures(features, nil, nil, nil)
+	var errs []error
 	for i, includeCase := range config.IncludeCases {
 		resolvedIncludes, err := resolveCase(features, includeCase)
 		if err != nil {
-			return nil, fmt.Errorf("%s: include case #%d: %w", configFileName, i+1, err)
+			errs = append(errs, prefixConfigErrors(err, fmt.Sprintf("include case #%d: ", i+1), "include_cases", i)...)
+			continue
+		}
+		if len(resolvedIncludes) == 0 {
+			errs = append(errs, newConfigError(fmt.Sprintf("include case #%d: config case does not match any config cases", i+1),
+				"include_cases", i))
 		}
 		for include := range resolvedIncludes {
 			cases[include] = struct{}{}
 		}
 	}
+	excluded := map[configCase]int{}
 	for i, excludeCase := range config.ExcludeCases {
 		resolvedExcludes, err := resolveCase(features, excludeCase)
 		if err != nil {
-			return nil, fmt.Errorf("%s: exclude case #%d: %w", configFileName, i+1, err)
+			errs = append(errs, prefixConfigErrors(err, fmt.Sprintf("exclude case #%d: ", i+1), "exclude_cases", i)...)
+			continue
 		}
+		var matched bool
 		for exclude := range resolvedExcludes {
-			delete(cases, exclude)
+			if _, ok := cases[exclude]; ok {
+				delete(cases, exclude)
+				excluded[exclude] = i + 1
+				matched = true
+			}
+		}
+		if !matched {
+			errs = append(errs, newConfigError(fmt.Sprintf("exclude case #%d: config case does not match any included config cases", i+1),
+				"exclude_cases", i))
 		}
 	}
-	if len(cases) == 0 {
-		return nil, fmt.Errorf("%s: configuration resulted in zero cases to test", configFileName)
+	if _, ok := conformancev1.StdioFormat_name[int32(config.StdioFormat)]; !ok {
+		errs = append(errs, newConfigError(fmt.Sprintf("unknown stdio format %d", config.StdioFormat), "stdio_format"))
 	}
-	casesSlice := make([]configCase, 0, len(cases))
-	for c := range cases {
-		casesSlice = append(casesSlice, c)
+	if len(errs) > 0 {
+		return nil, nil, locateConfigErrors(configFileName, data, errs)
 	}
-	return casesSlice, nil
+	return cases, excluded, nil
+}
+
+// parseStdioFormat returns the format of the messages exchanged with the
+// implementation under test over stdin and stdout, as given by the config
+// file data. If the config does not specify a format, the binary format
+// is used.
+func parseStdioFormat(configFileName string, data []byte) (conformancev1.StdioFormat, error) {
+	config, err := unmarshalConfig(configFileName, data)
+	if err != nil {
+		return 0, err
+	}
+	if config.StdioFormat == conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED {
+		return conformancev1.StdioFormat_STDIO_FORMAT_BINARY, nil
+	}
+	return config.StdioFormat, nil
+}
+
+func unmarshalConfig(configFileName string, data []byte) (*conformancev1.Config, error) {
+	var config conformancev1.Config
+	if len(data) > 0 {
+		opts := protoyaml.UnmarshalOptions{
+			Path: configFileName,
+		}
+		if err := opts.Unmarshal(data, &config); err != nil {
+			return nil, internal.EnsureFileName(err, configFileName)
+		}
+	}
+	return &config, nil
 }
 
 // resolveFeatures resolves all unspecified fields in the given features from the
@@ -141,9 +231,16 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 		result.SupportsMessageReceiveLimit = true
 	}
 
+	var errs []error
 	if result.SupportsTLSClientCerts && !result.SupportsTLS {
-		return result, errors.New("config features indicate TLS client certs are supported but not TLS")
-	}
+		errs = append(errs, newConfigError("config features indicate TLS client certs are supported but not TLS",
+			"supports_tls_client_certs"))
+	}
+	errs = appendUnspecifiedErrors(errs, "HTTP version", "versions", result.Versions)
+	errs = appendUnspecifiedErrors(errs, "protocol", "protocols", result.Protocols)
+	errs = appendUnspecifiedErrors(errs, "codec", "codecs", result.Codecs)
+	errs = appendUnspecifiedErrors(errs, "compression", "compressions", result.Compressions)
+	errs = appendUnspecifiedErrors(errs, "stream type", "stream_types", result.StreamTypes)
 
 	if len(result.Versions) == 0 {
 		if result.SupportsTLS || result.SupportsH2C {
@@ -157,17 +254,18 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 			}
 		}
 	} else if features.SupportsH2C != nil && features.GetSupportsH2C() && !contains(result.Versions, conformancev1.HTTPVersion_HTTP_VERSION_2) {
-		return result, errors.New("config features indicate H2C is supported but HTTP/2 is not a supported HTTP version")
+		errs = append(errs, newConfigError("config features indicate H2C is supported but HTTP/2 is not a supported HTTP version",
+			"supports_h2c"))
 	}
 
 	includesHTTP3 := contains(result.Versions, conformancev1.HTTPVersion_HTTP_VERSION_3)
 	if includesHTTP3 && !result.SupportsTLS {
-		return result, errors.New("config features indicate HTTP/3 is supported but TLS is not")
+		errs = append(errs, newConfigError("config features indicate HTTP/3 is supported but TLS is not", "versions"))
 	}
 	includesHTTP2 := contains(result.Versions, conformancev1.HTTPVersion_HTTP_VERSION_2)
 	canUseHTTP2 := result.SupportsH2C || result.SupportsTLS
 	if includesHTTP2 && !canUseHTTP2 {
-		return result, errors.New("config features indicate HTTP/2 is supported but neither H2C nor TLS are supported")
+		errs = append(errs, newConfigError("config features indicate HTTP/2 is supported but neither H2C nor TLS are supported", "versions"))
 	}
 	if len(result.Versions) == 0 {
 		if canUseHTTP2 {
@@ -183,10 +281,10 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 
 	includesGPRC := contains(result.Protocols, conformancev1.Protocol_PROTOCOL_GRPC)
 	if includesGPRC && !result.SupportsTrailers {
-		return result, errors.New("config features indicate gRPC protocol is supported but trailers are not")
+		errs = append(errs, newConfigError("config features indicate gRPC protocol is supported but trailers are not", "protocols"))
 	}
 	if includesGPRC && !includesHTTP2 {
-		return result, errors.New("config features indicate gRPC protocol is supported but HTTP/2 is not")
+		errs = append(errs, newConfigError("config features indicate gRPC protocol is supported but HTTP/2 is not", "protocols"))
 	}
 	canUseGRPC := result.SupportsTrailers && includesHTTP2
 	if len(result.Protocols) == 0 {
@@ -220,11 +318,13 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 	includesFullDuplex := contains(result.StreamTypes, conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM)
 	onlyHTTP1 := !includesHTTP2 && !includesHTTP3
 	if includesFullDuplex && onlyHTTP1 {
-		return result, errors.New("config features indicate full-duplex bidi streams are supported but neither HTTP/2 nor HTTP/3 included")
+		errs = append(errs, newConfigError("config features indicate full-duplex bidi streams are supported but neither HTTP/2 nor HTTP/3 included",
+			"stream_types"))
 	}
 	includesHalfDuplex := contains(result.StreamTypes, conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM)
 	if includesHalfDuplex && onlyHTTP1 && !result.SupportsHalfDuplexBidiOverHTTP1 {
-		return result, errors.New("config features indicate half-duplex bidi streams are supported but not over HTTP/1.1, and neither HTTP/2 nor HTTP/3 included")
+		errs = append(errs, newConfigError("config features indicate half-duplex bidi streams are supported but not over HTTP/1.1, and neither HTTP/2 nor HTTP/3 included",
+			"stream_types"))
 	}
 	if len(result.StreamTypes) == 0 { //nolint:nestif
 		if onlyHTTP1 {
@@ -253,7 +353,7 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 		}
 	}
 
-	return result, nil
+	return result, errors.Join(errs...)
 }
 
 // computeCasesFromFeatures expands the given features into all matching config
@@ -353,17 +453,18 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.ConfigCase) (map[configCase]struct{}, error) {
 	// Build a set of supportedFeatures that matches the given ConfigCase.
 	impliedFeatures := features // start as copy of supported features
+	var errs []error
 	if unresolvedCase.Version != conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED {
 		usingTLS := (unresolvedCase.UseTls != nil && unresolvedCase.GetUseTls()) ||
 			(unresolvedCase.UseTls == nil && features.SupportsTLS)
 		switch unresolvedCase.Version { //nolint:exhaustive
 		case conformancev1.HTTPVersion_HTTP_VERSION_2:
 			if !usingTLS && !features.SupportsH2C {
-				return nil, errors.New("config case indicates HTTP/2 but not TLS, and features indicate that H2C not supported")
+				errs = append(errs, newConfigError("config case indicates HTTP/2 but not TLS, and features indicate that H2C not supported", "version"))
 			}
 		case conformancev1.HTTPVersion_HTTP_VERSION_3:
 			if !usingTLS {
-				return nil, errors.New("config case indicates HTTP/3 but not TLS")
+				errs = append(errs, newConfigError("config case indicates HTTP/3 but not TLS", "version"))
 			}
 		}
 		impliedFeatures.Versions = []conformancev1.HTTPVersion{unresolvedCase.Version}
@@ -371,7 +472,10 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 	if unresolvedCase.Protocol != conformancev1.Protocol_PROTOCOL_UNSPECIFIED {
 		if unresolvedCase.Protocol == conformancev1.Protocol_PROTOCOL_GRPC &&
 			!contains(impliedFeatures.Versions, conformancev1.HTTPVersion_HTTP_VERSION_2) {
-			return nil, errors.New("config case indicates gRPC protocol but not HTTP/2")
+			errs = append(errs, newConfigError("config case indicates gRPC protocol but not HTTP/2", "protocol"))
+		}
+		if unresolvedCase.Protocol == conformancev1.Protocol_PROTOCOL_GRPC && !features.SupportsTrailers {
+			errs = append(errs, newConfigError("config case indicates gRPC protocol but features indicate that trailers are not supported", "protocol"))
 		}
 		impliedFeatures.Protocols = []conformancev1.Protocol{unresolvedCase.Protocol}
 	}
@@ -385,11 +489,13 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 		switch unresolvedCase.StreamType { //nolint:exhaustive
 		case conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM:
 			if !features.SupportsHalfDuplexBidiOverHTTP1 && only(impliedFeatures.Versions, conformancev1.HTTPVersion_HTTP_VERSION_1) {
-				return nil, errors.New("config case indicates half-duplex bidi stream type, but features indicate only HTTP/1.1 and that half-duplex is not supported over HTTP1.1")
+				errs = append(errs, newConfigError("config case indicates half-duplex bidi stream type, but features indicate only HTTP/1.1 and that half-duplex is not supported over HTTP1.1",
+					"stream_type"))
 			}
 		case conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
 			if only(impliedFeatures.Versions, conformancev1.HTTPVersion_HTTP_VERSION_1) {
-				return nil, errors.New("config case indicates full-duplex bidi stream type, but features indicate only HTTP/1.1 which cannot support full-duplex")
+				errs = append(errs, newConfigError("config case indicates full-duplex bidi stream type, but features indicate only HTTP/1.1 which cannot support full-duplex",
+					"stream_type"))
 			}
 		}
 		impliedFeatures.StreamTypes = []conformancev1.StreamType{unresolvedCase.StreamType}
@@ -401,17 +507,19 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 	if unresolvedCase.UseTlsClientCerts != nil {
 		if unresolvedCase.UseTls != nil && !unresolvedCase.GetUseTls() {
 			// use_tls explicitly set to false for this case?
-			return nil, errors.New("config case indicates use of TLS client certs but also indicates NOT using TLS")
-		}
-		if !contains(tlsCases, true) && !features.SupportsTLS {
+			errs = append(errs, newConfigError("config case indicates use of TLS client certs but also indicates NOT using TLS", "use_tls_client_certs"))
+		} else if !contains(tlsCases, true) && !features.SupportsTLS {
 			// TLS not supported?
-			return nil, errors.New("config case indicates use of TLS client certs but TLS is not supported")
+			errs = append(errs, newConfigError("config case indicates use of TLS client certs but TLS is not supported", "use_tls_client_certs"))
 		}
 		tlsClientCertCases = []bool{unresolvedCase.GetUseTlsClientCerts()}
 	}
 	if unresolvedCase.UseMessageReceiveLimit != nil {
 		msgReceiveLimitCases = []bool{unresolvedCase.GetUseMessageReceiveLimit()}
 	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	return computeCasesFromFeatures(impliedFeatures, tlsCases, tlsClientCertCases, msgReceiveLimitCases), nil
 }
 
@@ -435,3 +543,14 @@ func only[T comparable, S ~[]T](slice S, find T) bool {
 	}
 	return true
 }
+
+// sortedKeys returns the keys of the given map in sorted order, for
+// iterating over the map in a deterministic order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
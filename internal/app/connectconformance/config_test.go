@@ -574,6 +574,36 @@ func TestParseConfig_RejectsInvalidConfigurations(t *testing.T) {
                       - useTlsClientCerts: true`,
 			expectedErr: "config case indicates use of TLS client certs but TLS is not supported",
 		},
+		{
+			name: "included case: gRPC without trailers",
+			config: `
+                     features:
+                        supportsTrailers: false
+                     include_cases:
+                      - version: HTTP_VERSION_2
+                        protocol: PROTOCOL_GRPC`,
+			expectedErr: "config case indicates gRPC protocol but features indicate that trailers are not supported",
+		},
+		{
+			name: "features: unspecified value",
+			config: `features:
+                        codecs: [CODEC_PROTO, CODEC_UNSPECIFIED]`,
+			expectedErr: "config features include an unspecified codec",
+		},
+		{
+			name: "excluded case: matches nothing",
+			config: `
+                     features:
+                        codecs: [CODEC_PROTO]
+                     exclude_cases:
+                      - codec: CODEC_JSON`,
+			expectedErr: "exclude case #1: config case does not match any included config cases",
+		},
+		{
+			name:        "stdio format: unknown value",
+			config:      `stdioFormat: 7`,
+			expectedErr: "unknown stdio format 7",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -587,6 +617,25 @@ func TestParseConfig_RejectsInvalidConfigurations(t *testing.T) {
 	}
 }
 
+func TestParseStdioFormat(t *testing.T) {
+	t.Parallel()
+	format, err := parseStdioFormat("config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_BINARY, format)
+	format, err = parseStdioFormat("config.yaml", []byte(`features:
+  codecs: [CODEC_PROTO]
+`))
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_BINARY, format)
+	format, err = parseStdioFormat("config.yaml", []byte(`stdioFormat: STDIO_FORMAT_JSON
+`))
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_JSON, format)
+	_, err = parseStdioFormat("config.yaml", []byte(`stdioFormat: STDIO_FORMAT_XML
+`))
+	require.ErrorContains(t, err, "config.yaml:1:14")
+}
+
 func computePermutations(
 	versions []conformancev1.HTTPVersion,
 	protocols []conformancev1.Protocol,
@@ -714,3 +763,27 @@ func minus[T comparable](a, b []T) []T {
 	}
 	return results
 }
+
+func TestParseConfig_ReportsLocations(t *testing.T) {
+	t.Parallel()
+	_, err := parseConfig("config.yaml", []byte(`features:
+  versions: [HTTP_VERSION_1]
+  protocols: [PROTOCOL_CONNECT, PROTOCOL_GRPC]
+  supportsTls: false
+  supportsTlsClientCerts: true
+`))
+	require.EqualError(t, err, "config.yaml:5:3 config features indicate TLS client certs are supported but not TLS\n"+
+		"config.yaml:3:3 config features indicate gRPC protocol is supported but HTTP/2 is not")
+
+	_, err = parseConfig("config.yaml", []byte(`features:
+  codecs: [CODEC_PROTO]
+include_cases:
+- codec: CODEC_JSON
+- version: HTTP_VERSION_3
+  useTls: false
+exclude_cases:
+- codec: CODEC_TEXT
+`))
+	require.EqualError(t, err, "config.yaml:5:3 include case #2: config case indicates HTTP/3 but not TLS\n"+
+		"config.yaml:8:3 exclude case #1: config case does not match any included config cases")
+}
@@ -0,0 +1,177 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// configError is a problem in a config file that is caused by a particular
+// field. It records the path to that field so that the location of the
+// problem in the YAML source can be reported.
+type configError struct {
+	// Field names and list indexes, from the enclosing message down to
+	// the offending field. Field names are the proto names of the fields.
+	path []any
+	msg  string
+}
+
+func newConfigError(msg string, path ...any) *configError {
+	return &configError{path: path, msg: msg}
+}
+
+func (e *configError) Error() string {
+	return e.msg
+}
+
+// appendUnspecifiedErrors appends an error for each value in the given list
+// of features that is the zero (i.e. unspecified) value of its enum.
+func appendUnspecifiedErrors[T ~int32](errs []error, what, field string, values []T) []error {
+	for i, val := range values {
+		if val == 0 {
+			errs = append(errs, newConfigError(fmt.Sprintf("config features include an unspecified %s", what), field, i))
+		}
+	}
+	return errs
+}
+
+// prefixConfigErrors returns the errors in err, which may be the result of
+// errors.Join, with the given prefix added to their path and the given label
+// added to their message. Errors that are not config errors are converted to
+// config errors whose path is the prefix.
+func prefixConfigErrors(err error, label string, prefix ...any) []error {
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	results := make([]error, len(errs))
+	for i, err := range errs {
+		var cfgErr *configError
+		if !errors.As(err, &cfgErr) {
+			cfgErr = &configError{msg: err.Error()}
+		}
+		path := make([]any, 0, len(prefix)+len(cfgErr.path))
+		path = append(path, prefix...)
+		path = append(path, cfgErr.path...)
+		results[i] = &configError{path: path, msg: label + cfgErr.msg}
+	}
+	return results
+}
+
+// locateConfigErrors returns a single error that describes all of the given
+// errors, with each one prefixed by the file name and, for config errors, the
+// line and column in the given YAML source of the field that caused it.
+func locateConfigErrors(configFileName string, data []byte, errs []error) error {
+	var root yaml.Node
+	if len(data) > 0 {
+		// Any syntax errors will have already been reported when unmarshalling
+		// the config, so we can ignore the error here.
+		_ = yaml.Unmarshal(data, &root)
+	}
+	located := make([]error, len(errs))
+	for i, err := range errs {
+		var cfgErr *configError
+		if errors.As(err, &cfgErr) {
+			if node := findConfigNode(&root, cfgErr.path); node != nil {
+				located[i] = fmt.Errorf("%s:%d:%d %w", configFileName, node.Line, node.Column, err)
+				continue
+			}
+		}
+		located[i] = fmt.Errorf("%s: %w", configFileName, err)
+	}
+	return errors.Join(located...)
+}
+
+// findConfigNode returns the YAML node for the field with the given path in
+// a config file. See findYAMLNode.
+func findConfigNode(root *yaml.Node, path []any) *yaml.Node {
+	return findYAMLNode(root, (&conformancev1.Config{}).ProtoReflect().Descriptor(), path)
+}
+
+// findYAMLNode returns the YAML node for the field with the given path in
+// a YAML document that describes a message of the given type. If the field
+// is not present, the node for the nearest enclosing element that is present
+// is returned. It returns nil if no element of the path is present. For
+// fields in a mapping, the node returned is that of the key.
+func findYAMLNode(root *yaml.Node, msgDesc protoreflect.MessageDescriptor, path []any) *yaml.Node {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+	node := root.Content[0]
+	var found *yaml.Node
+	for _, elem := range path {
+		switch elem := elem.(type) {
+		case string:
+			if node.Kind != yaml.MappingNode || msgDesc == nil {
+				return found
+			}
+			field := msgDesc.Fields().ByName(protoreflect.Name(elem))
+			if field == nil {
+				return found
+			}
+			var value *yaml.Node
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				key := node.Content[j]
+				if key.Value == string(field.Name()) || key.Value == field.JSONName() {
+					found, value = key, node.Content[j+1]
+					break
+				}
+			}
+			if value == nil {
+				return found
+			}
+			node, msgDesc = value, field.Message()
+		case int:
+			if node.Kind != yaml.SequenceNode || elem >= len(node.Content) {
+				return found
+			}
+			node = node.Content[elem]
+			found = node
+		}
+	}
+	return found
+}
+
+// ValidateConfig checks each of the given config files for problems, such
+// as impossible or contradictory combinations of features, or include and
+// exclude cases that match nothing. Any problems found are printed, along
+// with their location in the file. It returns true if no problems are found.
+// An error is returned if a file cannot be read.
+func ValidateConfig(fileNames []string, printer internal.Printer) (bool, error) {
+	allValid := true
+	for _, fileName := range fileNames {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return false, internal.EnsureFileName(err, fileName)
+		}
+		configCases, err := parseConfig(fileName, data)
+		if err != nil {
+			printer.Printf("%v", err)
+			allValid = false
+			continue
+		}
+		printer.Printf("%s: OK (%d config cases)", fileName, len(configCases))
+	}
+	return allValid, nil
+}
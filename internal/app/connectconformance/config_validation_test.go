@@ -0,0 +1,98 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.yaml")
+	err := os.WriteFile(validFile, []byte(`features:
+  versions: [HTTP_VERSION_1]
+  protocols: [PROTOCOL_CONNECT]
+  codecs: [CODEC_PROTO]
+  compressions: [COMPRESSION_IDENTITY]
+  streamTypes: [STREAM_TYPE_UNARY]
+  supportsTls: false
+  supportsConnectGet: false
+  supportsMessageReceiveLimit: false
+`), 0600)
+	require.NoError(t, err)
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	err = os.WriteFile(invalidFile, []byte(`features:
+  supportsTls: false
+  supportsTlsClientCerts: true
+`), 0600)
+	require.NoError(t, err)
+
+	printer := &internal.SimplePrinter{}
+	ok, err := ValidateConfig([]string{validFile, invalidFile}, printer)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, []string{
+		validFile + ": OK (1 config cases)\n",
+		invalidFile + ":3:3 config features indicate TLS client certs are supported but not TLS\n",
+	}, printer.Messages)
+
+	ok, err = ValidateConfig([]string{validFile}, &internal.SimplePrinter{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = ValidateConfig([]string{filepath.Join(dir, "missing.yaml")}, &internal.SimplePrinter{})
+	require.ErrorContains(t, err, "missing.yaml")
+}
+
+func TestFindConfigNode(t *testing.T) {
+	t.Parallel()
+	var root yaml.Node
+	err := yaml.Unmarshal([]byte(`features:
+  supports_tls: false
+  codecs:
+  - CODEC_PROTO
+  - CODEC_JSON
+includeCases:
+- version: HTTP_VERSION_2
+  useTls: true
+`), &root)
+	require.NoError(t, err)
+	testCases := []struct {
+		path         []any
+		line, column int
+	}{
+		{path: []any{"features", "supports_tls"}, line: 2, column: 3},
+		{path: []any{"features", "codecs", 1}, line: 5, column: 5},
+		// missing field resolves to its parent
+		{path: []any{"features", "versions"}, line: 1, column: 1},
+		{path: []any{"include_cases", 0, "use_tls"}, line: 8, column: 3},
+		{path: []any{"include_cases", 3}, line: 6, column: 1},
+	}
+	for _, testCase := range testCases {
+		node := findConfigNode(&root, testCase.path)
+		require.NotNil(t, node, "%v", testCase.path)
+		assert.Equal(t, testCase.line, node.Line, "%v", testCase.path)
+		assert.Equal(t, testCase.column, node.Column, "%v", testCase.path)
+	}
+	assert.Nil(t, findConfigNode(&root, []any{"exclude_cases", 0}))
+}
@@ -42,6 +42,9 @@ type Flags struct {
 	ConfigFile           string
 	RunPatterns          []string
 	SkipPatterns         []string
+	Tags                 []string
+	SkipTags             []string
+	Dimensions           DimensionFlags
 	KnownFailingPatterns []string
 	KnownFlakyPatterns   []string
 	Verbose              bool
@@ -56,69 +59,235 @@ type Flags struct {
 	ServerPort           uint
 	ServerBind           string
 	HTTPTrace            bool
+	JUnitReportFile      string
+	JSONReportFile       string
+	FlakyRetries         uint
+	Strict               bool
+	MaxFailures          uint
+	KnownFailingOutFile  string
+	StateFile            string
+	RerunFailed          bool
+	BaselineFile         string
+	ShardIndex           uint
+	ShardCount           uint
+	Progress             ProgressMode
+	// The writer to which the status line is written when Progress
+	// is ProgressTerminal.
+	ProgressWriter io.Writer
+	// If non-nil, test cases are run against this already-running
+	// server, instead of starting ServerCommand.
+	ExternalServer *ExternalServer
+	// If non-empty, the client under test receives test cases and reports
+	// results over HTTP, using a control channel served at this address,
+	// instead of over stdin and stdout. In that case, ClientCommand may be
+	// empty, and the client is started separately.
+	ClientControlAddr string
+	// If non-empty, the control channel allows cross-origin requests from
+	// this origin, so it can be used by a client that runs in a browser.
+	// The value "*" allows requests from any origin.
+	ClientControlOrigin string
+	// If true, the implementation under test is first started just to
+	// report its own config, which is merged with the config file.
+	Handshake bool
+	// If specified, the format of the messages exchanged with the client
+	// or server under test, respectively, over stdin and stdout. Otherwise,
+	// the format in the config file is used. These allow a client and a
+	// server that use different formats to be tested together.
+	ClientStdioFormat conformancev1.StdioFormat
+	ServerStdioFormat conformancev1.StdioFormat
 }
 
 func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer) (bool, error) {
-	var configData []byte
-	if flags.ConfigFile != "" {
+	var selfReported *selfReportedConfig
+	if flags.Handshake {
 		var err error
-		if configData, err = os.ReadFile(flags.ConfigFile); err != nil {
-			return false, internal.EnsureFileName(err, flags.ConfigFile)
+		if selfReported, err = runHandshake(context.Background(), flags); err != nil {
+			return false, err
 		}
-	} else if flags.Verbose {
-		logPrinter.Printf("No config file provided. Using defaults.")
 	}
-	configCases, err := parseConfig(flags.ConfigFile, configData)
+	configCases, err := loadFilteredConfig(flags.ConfigFile, selfReported, flags.Dimensions, flags.Verbose, logPrinter)
 	if err != nil {
 		return false, err
 	}
-	if flags.Verbose {
-		logPrinter.Printf("Computed %d config case permutations.", len(configCases))
+
+	var baseline map[string]*resultRecord
+	if flags.BaselineFile != "" {
+		if baseline, err = readBaseline(flags.BaselineFile); err != nil {
+			return false, err
+		}
 	}
 
-	knownFailing := parsePatterns(flags.KnownFailingPatterns)
+	// Patterns that name tags are handled separately, in run.
+	knownFailingNames, _ := splitTagPatterns(flags.KnownFailingPatterns)
+	knownFailing := parsePatterns(knownFailingNames)
 	if knownFailing == nil {
 		// treat as empty
 		knownFailing = &testTrie{}
 	}
-	knownFlaky := parsePatterns(flags.KnownFlakyPatterns)
+	knownFlakyNames, _ := splitTagPatterns(flags.KnownFlakyPatterns)
+	knownFlaky := parsePatterns(knownFlakyNames)
 	if knownFlaky == nil {
 		// treat as empty
 		knownFlaky = &testTrie{}
 	}
 
+	var previouslyFailed []string
+	if flags.StateFile != "" {
+		if previouslyFailed, err = readFailedState(flags.StateFile); err != nil {
+			return false, err
+		}
+	}
+
 	runPatterns := parsePatterns(flags.RunPatterns)
+	if flags.RerunFailed {
+		if len(previouslyFailed) == 0 {
+			logPrinter.Printf("No test cases failed in the previous run; nothing to rerun.")
+			return true, nil
+		}
+		runPatterns = parsePatterns(previouslyFailed)
+	}
 	skipPatterns := parsePatterns(flags.SkipPatterns)
 
+	allSuites, err := loadTestSuites(flags.TestFiles, flags.Verbose, logPrinter)
+	if err != nil {
+		return false, err
+	}
+
+	results, err := run(configCases, knownFailing, knownFlaky, runPatterns, skipPatterns, allSuites, logPrinter, errPrinter, flags)
+	if err != nil {
+		return false, err
+	}
+	ok := results.report(logPrinter)
+	if (len(flags.ClientCommand) > 0 || flags.ClientControlAddr != "") && len(flags.ServerCommand) > 0 {
+		results.reportInteropMatrix(logPrinter)
+	}
+	if len(previouslyFailed) > 0 {
+		results.reportPreviouslyFailed(logPrinter, previouslyFailed)
+	}
+	if len(flags.KnownFailingPatterns) > 0 && !results.dispatchStopped() {
+		// When stopped early, patterns for test cases that were skipped would look stale.
+		results.reportStaleKnownFailing(logPrinter, flags.KnownFailingPatterns)
+	}
+	if baseline != nil {
+		// When comparing to a baseline, only new failures cause the run to fail.
+		ok = results.compareToBaseline(baseline).report(logPrinter)
+	}
+	if flags.JUnitReportFile != "" {
+		if err := results.writeJUnitReport(flags.JUnitReportFile); err != nil {
+			return false, fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+	}
+	if flags.JSONReportFile != "" {
+		if err := results.writeJSONReport(flags.JSONReportFile); err != nil {
+			return false, fmt.Errorf("failed to write JSON report: %w", err)
+		}
+	}
+	if flags.KnownFailingOutFile != "" {
+		if err := results.writeKnownFailing(flags.KnownFailingOutFile); err != nil {
+			return false, fmt.Errorf("failed to write known-failing file: %w", err)
+		}
+	}
+	if flags.StateFile != "" {
+		if err := results.writeFailedState(flags.StateFile, previouslyFailed); err != nil {
+			return false, fmt.Errorf("failed to write state file: %w", err)
+		}
+	}
+	return ok, nil
+}
+
+// loadConfig loads the named config file and computes the config cases
+// that it describes. If fileName is empty, the default config is used. If
+// selfReported is non-nil, it is merged with the config from the file.
+func loadConfig(fileName string, selfReported *selfReportedConfig, verbose bool, logPrinter internal.Printer) ([]configCase, error) {
+	var configData []byte
+	if fileName != "" {
+		var err error
+		if configData, err = os.ReadFile(fileName); err != nil {
+			return nil, internal.EnsureFileName(err, fileName)
+		}
+	} else if verbose {
+		if selfReported != nil {
+			logPrinter.Printf("No config file provided. Using the config reported by the %s.", selfReported.reporter)
+		} else {
+			logPrinter.Printf("No config file provided. Using defaults.")
+		}
+	}
+	var configCases []configCase
+	var err error
+	if selfReported != nil {
+		configCases, err = parseConfigWithSelfReported(fileName, configData, selfReported, logPrinter)
+	} else {
+		configCases, err = parseConfig(fileName, configData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if verbose {
+		logPrinter.Printf("Computed %d config case permutations.", len(configCases))
+	}
+	return configCases, nil
+}
+
+// loadStdioFormat loads the named config file and returns the format of
+// the messages exchanged with the implementation under test over stdin and
+// stdout. If fileName is empty, the default format is used.
+func loadStdioFormat(fileName string) (conformancev1.StdioFormat, error) {
+	var configData []byte
+	if fileName != "" {
+		var err error
+		if configData, err = os.ReadFile(fileName); err != nil {
+			return 0, internal.EnsureFileName(err, fileName)
+		}
+	}
+	return parseStdioFormat(fileName, configData)
+}
+
+// loadStdioFormats returns the formats of the messages exchanged with the
+// client and server under test over stdin and stdout. A format given in
+// flags takes precedence over the one in the config file.
+func loadStdioFormats(flags *Flags) (clientFormat, serverFormat conformancev1.StdioFormat, err error) {
+	configFormat, err := loadStdioFormat(flags.ConfigFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	clientFormat, serverFormat = configFormat, configFormat
+	if flags.ClientStdioFormat != conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED {
+		clientFormat = flags.ClientStdioFormat
+	}
+	if flags.ServerStdioFormat != conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED {
+		serverFormat = flags.ServerStdioFormat
+	}
+	return clientFormat, serverFormat, nil
+}
+
+// loadTestSuites loads the test suites in the given files. If no files
+// are given, the embedded test suites are loaded.
+func loadTestSuites(testFiles []string, verbose bool, logPrinter internal.Printer) (map[string]*conformancev1.TestSuite, error) {
 	var testSuiteData map[string][]byte
-	if len(flags.TestFiles) > 0 {
-		testSuiteData, err = testsuites.LoadTestSuitesFromFiles(flags.TestFiles)
+	var err error
+	if len(testFiles) > 0 {
+		testSuiteData, err = testsuites.LoadTestSuitesFromFiles(testFiles)
 		if err != nil {
-			return false, fmt.Errorf("failed to load test suite data: %w", err)
+			return nil, fmt.Errorf("failed to load test suite data: %w", err)
 		}
 	} else {
 		testSuiteData, err = testsuites.LoadTestSuites()
 		if err != nil {
-			return false, fmt.Errorf("failed to load embedded test suite data: %w", err)
+			return nil, fmt.Errorf("failed to load embedded test suite data: %w", err)
 		}
 	}
 	allSuites, err := parseTestSuites(testSuiteData)
 	if err != nil {
-		return false, fmt.Errorf("embedded test suite: %w", err)
+		return nil, fmt.Errorf("embedded test suite: %w", err)
 	}
-	if flags.Verbose {
+	if verbose {
 		var numCases int
 		for _, suite := range allSuites {
 			numCases += len(suite.TestCases)
 		}
 		logPrinter.Printf("Loaded %d test suite(s), %d test case template(s).", len(allSuites), numCases)
 	}
-
-	results, err := run(configCases, knownFailing, knownFlaky, runPatterns, skipPatterns, allSuites, logPrinter, errPrinter, flags)
-	if err != nil {
-		return false, err
-	}
-	return results.report(logPrinter), nil
+	return allSuites, nil
 }
 
 func run( //nolint:gocyclo
@@ -132,21 +301,25 @@ func run( //nolint:gocyclo
 	errPrinter internal.Printer,
 	flags *Flags,
 ) (*testResults, error) {
-	mode := conformancev1.TestSuite_TEST_MODE_UNSPECIFIED
-	useReferenceClient := len(flags.ClientCommand) == 0
-	useReferenceServer := len(flags.ServerCommand) == 0
-	switch {
-	case useReferenceServer && !useReferenceClient:
-		// Client mode uses a reference server to test a given client
-		mode = conformancev1.TestSuite_TEST_MODE_CLIENT
-	case useReferenceClient && !useReferenceServer:
-		// Server mode uses a reference client to test a given server
-		mode = conformancev1.TestSuite_TEST_MODE_SERVER
-	default:
-		// Otherwise, leave mode as "unspecified" so we'll include
-		// neither client-specific nor server-specific cases.
+	var extServer *externalServer
+	if flags.ExternalServer != nil {
+		var err error
+		if extServer, err = newExternalServer(flags.ExternalServer); err != nil {
+			return nil, err
+		}
+		if configCases, err = extServer.apply(configCases); err != nil {
+			return nil, err
+		}
+		if flags.Verbose {
+			logPrinter.Printf("Filtered to %d config case permutations compatible with the %s.", len(configCases), extServer)
+		}
 	}
-	testCaseLib, err := newTestCaseLibrary(allSuites, configCases, mode)
+	useReferenceClient := len(flags.ClientCommand) == 0 && flags.ClientControlAddr == ""
+	useReferenceServer := len(flags.ServerCommand) == 0 && extServer == nil
+	// In "both" mode, the client and server under test are also each run
+	// against a reference implementation.
+	interop := !useReferenceClient && !useReferenceServer
+	testCaseLib, err := newTestCaseLibraryForRun(allSuites, configCases, useReferenceClient, useReferenceServer)
 	if err != nil {
 		return nil, err
 	}
@@ -178,8 +351,42 @@ func run( //nolint:gocyclo
 				knownFlaky.length(), matched)
 		}
 	}
+	_, knownFailingTags := splitTagPatterns(flags.KnownFailingPatterns)
+	if len(knownFailingTags) > 0 {
+		matched, err := tryMatchTags("known failing", knownFailingTags, allPermutations)
+		if err != nil {
+			return nil, err
+		}
+		if flags.Verbose {
+			logPrinter.Printf("Loaded %d known failing tag(s) that match %d test case permutation(s).",
+				len(knownFailingTags), matched)
+		}
+	}
+	_, knownFlakyTags := splitTagPatterns(flags.KnownFlakyPatterns)
+	if len(knownFlakyTags) > 0 {
+		matched, err := tryMatchTags("known flaky", knownFlakyTags, allPermutations)
+		if err != nil {
+			return nil, err
+		}
+		if flags.Verbose {
+			logPrinter.Printf("Loaded %d known flaky tag(s) that match %d test case permutation(s).",
+				len(knownFlakyTags), matched)
+		}
+	}
 	if run != nil {
-		if _, err := tryMatchPatterns("run patterns", run, allPermutations); err != nil {
+		matched, err := tryMatchPatterns("run patterns", run, allPermutations)
+		switch {
+		case flags.RerunFailed:
+			// Test cases that failed in the previous run may no longer exist if the
+			// config or test suites have changed since then, so unmatched names are
+			// ignored instead of being reported as errors.
+			if matched == 0 {
+				return nil, errors.New("none of the test cases that failed in the previous run match any current test case")
+			}
+			if flags.Verbose {
+				logPrinter.Printf("Rerunning %d test case(s) that failed in the previous run.", matched)
+			}
+		case err != nil:
 			return nil, err
 		}
 	}
@@ -188,12 +395,24 @@ func run( //nolint:gocyclo
 			return nil, err
 		}
 	}
+	if len(flags.Tags) > 0 {
+		if _, err := tryMatchTags("run tags", flags.Tags, allPermutations); err != nil {
+			return nil, err
+		}
+	}
+	if len(flags.SkipTags) > 0 {
+		if _, err := tryMatchTags("no-run tags", flags.SkipTags, allPermutations); err != nil {
+			return nil, err
+		}
+	}
 	// we don't allow ambiguity whether a file is known to fail vs known to be flaky
-	if knownFailing.length() > 0 && knownFlaky.length() > 0 {
+	if (knownFailing.length() > 0 || len(knownFailingTags) > 0) && (knownFlaky.length() > 0 || len(knownFlakyTags) > 0) {
 		var conflicts []string
 		for _, testCase := range allPermutations {
 			name := testCase.Request.TestName
-			if knownFailing.matchPattern(name) && knownFlaky.matchPattern(name) {
+			isKnownFailing := knownFailing.matchPattern(name) || hasAnyTag(testCase.Tags, knownFailingTags)
+			isKnownFlaky := knownFlaky.matchPattern(name) || hasAnyTag(testCase.Tags, knownFlakyTags)
+			if isKnownFailing && isKnownFlaky {
 				conflicts = append(conflicts, name)
 			}
 		}
@@ -203,7 +422,7 @@ func run( //nolint:gocyclo
 		}
 	}
 
-	filter := newFilter(run, skip)
+	filter := newFilter(run, skip, flags.Tags, flags.SkipTags)
 	if flags.Verbose { //nolint:nestif
 		logPrinter.Printf("Computed %d test case permutation(s) across %d server configuration(s).",
 			len(allPermutations), len(testCaseLib.casesByServer))
@@ -228,6 +447,15 @@ func run( //nolint:gocyclo
 		}
 	}
 
+	shard, err := newShard(testCaseLib, allPermutations, filter, flags.ShardIndex, flags.ShardCount)
+	if err != nil {
+		return nil, err
+	}
+	if shard != nil && flags.Verbose {
+		logPrinter.Printf("Running shard %d of %d: %d test case(s) across %d server configuration(s).",
+			flags.ShardIndex+1, flags.ShardCount, len(shard.names), shard.numServers)
+	}
+
 	var clientCreds *conformancev1.ClientCompatRequest_TLSCreds
 	for svrInstance := range testCaseLib.casesByServer {
 		if svrInstance.useTLSClientCerts {
@@ -243,6 +471,14 @@ func run( //nolint:gocyclo
 		}
 	}
 
+	// The reference implementations, and the stand-in for an external
+	// server, always use the binary format. The config file describes the
+	// implementation under test, so its format applies only to that.
+	clientStdioFormat, serverStdioFormat, err := loadStdioFormats(flags)
+	if err != nil {
+		return nil, err
+	}
+
 	var trace *tracer.Tracer
 	if flags.HTTPTrace {
 		trace = &tracer.Tracer{}
@@ -250,20 +486,54 @@ func run( //nolint:gocyclo
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	// This is canceled when the maximum number of failures is reached. It stops
+	// dispatching test cases, but test cases that were already sent to the client
+	// may still complete.
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	defer stopDispatch()
+
+	// Progress is reported via errPrinter, so that periodic progress logs
+	// aren't mixed into the report.
+	progress := newProgress(flags.Progress, flags.ProgressWriter, errPrinter)
+	logPrinter, errPrinter = progress.wrap(logPrinter), progress.wrap(errPrinter)
+
+	clientUnderTest := processInfo{
+		start:       runCommand(flags.ClientCommand),
+		stdioFormat: clientStdioFormat,
+	}
+	if flags.ClientControlAddr != "" {
+		ctrl, err := newControlChannel(flags.ClientControlAddr, flags.ClientControlOrigin, flags.ClientCommand, errPrinter)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = ctrl.close()
+		}()
+		if len(flags.ClientCommand) == 0 {
+			logPrinter.Printf("Waiting for the client under test to connect to the %s.", ctrl)
+		}
+		// The control channel relays messages in the binary format; the client
+		// chooses the format it uses over HTTP.
+		clientUnderTest = processInfo{
+			start: ctrl.start(),
+		}
+	}
 
+	referenceClient := processInfo{
+		name: "reference client",
+		start: runInProcess([]string{
+			"reference-client",
+			"-p", strconv.Itoa(int(flags.Parallelism)),
+		}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+			return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+		}),
+		isReferenceImpl: true,
+	}
 	var clients []processInfo
-	if useReferenceClient {
+	switch {
+	case useReferenceClient:
 		clients = []processInfo{
-			{
-				name: "reference client",
-				start: runInProcess([]string{
-					"reference-client",
-					"-p", strconv.Itoa(int(flags.Parallelism)),
-				}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-					return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
-				}),
-				isReferenceImpl: true,
-			},
+			referenceClient,
 			{
 				name: "reference client (grpc)",
 				start: runInProcess([]string{
@@ -273,57 +543,106 @@ func run( //nolint:gocyclo
 				isGrpcImpl: true,
 			},
 		}
-	} else {
-		clients = []processInfo{
+	case interop:
+		clientUnderTest.name = "client under test"
+		clients = []processInfo{clientUnderTest, referenceClient}
+	default:
+		clients = []processInfo{clientUnderTest}
+	}
+
+	referenceServer := processInfo{
+		name: "reference server",
+		start: runInProcess([]string{
+			"reference-server",
+			"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
+			"-bind", flags.ServerBind,
+			"-cert", flags.TLSCertFile,
+			"-key", flags.TLSKeyFile,
+		}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+			return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+		}),
+		isReferenceImpl: true,
+	}
+	var servers []processInfo
+	switch {
+	case useReferenceServer:
+		servers = []processInfo{
+			referenceServer,
 			{
-				start: runCommand(flags.ClientCommand),
+				name: "reference server (grpc)",
+				start: runInProcess([]string{
+					"grpc-reference-server",
+					"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
+					"-bind", flags.ServerBind,
+				}, grpcserver.Run),
+				isGrpcImpl: true,
 			},
 		}
+	case extServer != nil:
+		servers = []processInfo{
+			{
+				name:  extServer.String(),
+				start: extServer.start(),
+			},
+		}
+	case interop:
+		servers = []processInfo{
+			{
+				name:        "server under test",
+				start:       runCommand(flags.ServerCommand),
+				stdioFormat: serverStdioFormat,
+			},
+			referenceServer,
+		}
+	default:
+		servers = []processInfo{
+			{
+				start:       runCommand(flags.ServerCommand),
+				stdioFormat: serverStdioFormat,
+			},
+		}
+	}
+
+	// testCasesFor returns the test cases to run with the given client and server
+	// for the given server instance.
+	testCasesFor := func(clientInfo, serverInfo processInfo, svrInstance serverInstance) []*conformancev1.TestCase {
+		testCases := testCaseLib.casesByServer[svrInstance]
+		if interop {
+			testCases = filterInteropTestCases(testCases, clientInfo.isReferenceImpl, serverInfo.isReferenceImpl)
+		}
+		testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
+		testCases = filter.apply(testCases)
+		return shard.apply(testCases)
 	}
 
+	var total int
+	for _, clientInfo := range clients {
+		for _, serverInfo := range servers {
+			for _, svrInstance := range svrInstances {
+				total += len(testCasesFor(clientInfo, serverInfo, svrInstance))
+			}
+		}
+	}
+	progress.run(total)
+	defer progress.stop()
+
 	results := newResults(knownFailing, knownFlaky, trace)
+	results.knownFailingTags = knownFailingTags
+	results.knownFlakyTags = knownFlakyTags
+	results.flakyRetries = flags.FlakyRetries
+	results.strict = flags.Strict
+	results.maxFailures = flags.MaxFailures
+	results.stopDispatch = stopDispatch
+	results.progress = progress
+	results.filtered = filter != nil || shard != nil || !flags.Dimensions.empty()
 
 	for _, clientInfo := range clients {
-		clientProcess, err := runClient(ctx, clientInfo.start)
+		clientProcess, err := runClient(ctx, clientInfo.start, newStdioCodec(clientInfo.stdioFormat))
 		if err != nil {
 			return nil, fmt.Errorf("error starting client: %w", err)
 		}
 		defer clientProcess.stop()
 
-		var servers []processInfo
-		if useReferenceServer {
-			servers = []processInfo{
-				{
-					name: "reference server",
-					start: runInProcess([]string{
-						"reference-server",
-						"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
-						"-bind", flags.ServerBind,
-						"-cert", flags.TLSCertFile,
-						"-key", flags.TLSKeyFile,
-					}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-						return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
-					}),
-					isReferenceImpl: true,
-				},
-				{
-					name: "reference server (grpc)",
-					start: runInProcess([]string{
-						"grpc-reference-server",
-						"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
-						"-bind", flags.ServerBind,
-					}, grpcserver.Run),
-					isGrpcImpl: true,
-				},
-			}
-		} else {
-			servers = []processInfo{
-				{
-					start: runCommand(flags.ServerCommand),
-				},
-			}
-		}
-
 		err = func() error {
 			var wg sync.WaitGroup
 			defer wg.Wait()
@@ -331,27 +650,31 @@ func run( //nolint:gocyclo
 
 			for _, serverInfo := range servers {
 				for _, svrInstance := range svrInstances {
-					testCases := testCaseLib.casesByServer[svrInstance]
-					testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
-					testCases = filter.apply(testCases)
+					testCases := testCasesFor(clientInfo, serverInfo, svrInstance)
 					if len(testCases) == 0 {
 						continue
 					}
 
-					if err := sema.Acquire(ctx, 1); err != nil {
-						return err
+					if dispatchCtx.Err() != nil {
+						results.skip(testCases)
+						continue
+					}
+					if err := sema.Acquire(dispatchCtx, 1); err != nil {
+						// The maximum number of failures was reached while waiting.
+						results.skip(testCases)
+						continue
 					}
 
+					var with string
+					switch {
+					case clientInfo.name != "" && serverInfo.name != "":
+						with = clientInfo.name + " and " + serverInfo.name
+					case clientInfo.name != "":
+						with = clientInfo.name
+					case serverInfo.name != "":
+						with = serverInfo.name
+					}
 					if flags.Verbose {
-						var with string
-						switch {
-						case clientInfo.name != "" && serverInfo.name != "":
-							with = clientInfo.name + " and " + serverInfo.name
-						case clientInfo.name != "":
-							with = clientInfo.name
-						case serverInfo.name != "":
-							with = serverInfo.name
-						}
 						logTestCaseInfo(with, svrInstance, len(testCases), logPrinter)
 					}
 
@@ -366,18 +689,27 @@ func run( //nolint:gocyclo
 						return err
 					}
 
+					results.addTestCases(testCases)
 					wg.Add(1)
+					description := serverConfigDescription(svrInstance)
+					if with != "" {
+						description = with + " for " + description
+					}
 					go func(ctx context.Context, clientInfo processInfo, serverInfo processInfo, svrInstance serverInstance) {
 						defer wg.Done()
 						defer sema.Release(1)
+						progress.serverStarted()
+						defer progress.serverStopped()
 						runTestCasesForServer(
 							ctx,
 							clientInfo.isReferenceImpl,
 							serverInfo.isReferenceImpl,
 							svrInstance,
+							description,
 							testCases,
 							clientCreds,
 							serverInfo.start,
+							newStdioCodec(serverInfo.stdioFormat),
 							logPrinter,
 							errPrinter,
 							results,
@@ -404,6 +736,52 @@ func run( //nolint:gocyclo
 	return results, nil
 }
 
+// referenceImplsForMode returns whether reference implementations are used
+// for the client and server, based on the given mode name, which must be
+// "client", "server", or "both".
+func referenceImplsForMode(mode string) (useReferenceClient, useReferenceServer bool, err error) {
+	switch mode {
+	case "client":
+		return false, true, nil
+	case "server":
+		return true, false, nil
+	case "both":
+		return false, false, nil
+	default:
+		return false, false, fmt.Errorf("invalid mode: expecting \"client\", \"server\", or \"both\"; got %q", mode)
+	}
+}
+
+// newTestCaseLibraryForRun creates the library of test cases to run, based on
+// whether reference implementations are used for the client and server.
+func newTestCaseLibraryForRun(
+	allSuites map[string]*conformancev1.TestSuite,
+	configCases []configCase,
+	useReferenceClient, useReferenceServer bool,
+) (*testCaseLibrary, error) {
+	if !useReferenceClient && !useReferenceServer {
+		return newInteropTestCaseLibrary(allSuites, configCases)
+	}
+	return newTestCaseLibrary(allSuites, configCases, testMode(useReferenceClient, useReferenceServer))
+}
+
+// testMode returns the mode of test suites to include, based on whether
+// reference implementations are used for the client and server.
+func testMode(useReferenceClient, useReferenceServer bool) conformancev1.TestSuite_TestMode {
+	switch {
+	case useReferenceServer && !useReferenceClient:
+		// Client mode uses a reference server to test a given client
+		return conformancev1.TestSuite_TEST_MODE_CLIENT
+	case useReferenceClient && !useReferenceServer:
+		// Server mode uses a reference client to test a given server
+		return conformancev1.TestSuite_TEST_MODE_SERVER
+	default:
+		// Otherwise, leave mode as "unspecified" so we'll include
+		// neither client-specific nor server-specific cases.
+		return conformancev1.TestSuite_TEST_MODE_UNSPECIFIED
+	}
+}
+
 func serverInstancesSlice(testCaseLib *testCaseLibrary, sorted bool) []serverInstance {
 	svrInstances := make([]serverInstance, 0, len(testCaseLib.casesByServer))
 	for svrInstance := range testCaseLib.casesByServer {
@@ -428,6 +806,11 @@ func serverInstancesSlice(testCaseLib *testCaseLibrary, sorted bool) []serverIns
 }
 
 func logTestCaseInfo(with string, svrInstance serverInstance, numCases int, logPrinter internal.Printer) {
+	logPrinter.Printf("Running %d tests with %s for %s...",
+		numCases, with, serverConfigDescription(svrInstance))
+}
+
+func serverConfigDescription(svrInstance serverInstance) string {
 	var tlsMode string
 	switch {
 	case !svrInstance.useTLS:
@@ -437,8 +820,8 @@ func logTestCaseInfo(with string, svrInstance serverInstance, numCases int, logP
 	default:
 		tlsMode = "true"
 	}
-	logPrinter.Printf("Running %d tests with %s for server config {%s, %s, TLS:%s}...",
-		numCases, with, svrInstance.httpVersion, svrInstance.protocol, tlsMode)
+	return fmt.Sprintf("server config {%s, %s, TLS:%s}",
+		svrInstance.httpVersion, svrInstance.protocol, tlsMode)
 }
 
 func tryMatchPatterns(what string, patterns *testTrie, testCases []*conformancev1.TestCase) (int, error) {
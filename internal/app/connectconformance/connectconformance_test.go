@@ -16,6 +16,8 @@ package connectconformance
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
@@ -123,3 +125,32 @@ func (t testPrinter) PrefixPrintf(prefix, msg string, args ...any) {
 	msg = fmt.Sprintf(msg, args...)
 	t.t.Logf("%s: %s", prefix, msg)
 }
+
+func TestLoadStdioFormats(t *testing.T) {
+	t.Parallel()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configFile, []byte(`stdioFormat: STDIO_FORMAT_JSON
+`), 0600)
+	require.NoError(t, err)
+
+	clientFormat, serverFormat, err := loadStdioFormats(&Flags{ConfigFile: configFile})
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_JSON, clientFormat)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_JSON, serverFormat)
+
+	// Formats in flags override the config file, separately for client and server.
+	clientFormat, serverFormat, err = loadStdioFormats(&Flags{
+		ConfigFile:        configFile,
+		ServerStdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_BINARY,
+	})
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_JSON, clientFormat)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_BINARY, serverFormat)
+
+	clientFormat, serverFormat, err = loadStdioFormats(&Flags{
+		ClientStdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_JSON,
+	})
+	require.NoError(t, err)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_JSON, clientFormat)
+	require.Equal(t, conformancev1.StdioFormat_STDIO_FORMAT_BINARY, serverFormat)
+}
@@ -0,0 +1,438 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// controlChannelURLEnvVar is the name of the environment variable that
+	// tells a client command where to find the control channel, when the
+	// client under test is driven over HTTP instead of stdin and stdout.
+	controlChannelURLEnvVar = "CONNECTCONFORMANCE_CONTROL_URL"
+
+	controlChannelNextPath   = "/next"
+	controlChannelResultPath = "/result"
+
+	// How long a request for the next test case waits for one to become
+	// available before telling the client to ask again.
+	defaultControlChannelPollTimeout = 10 * time.Second
+	// The maximum size of a result posted by the client.
+	maxControlChannelResultSize = 64 * 1024 * 1024
+)
+
+// controlChannel is an HTTP endpoint through which a client under test
+// receives test cases and reports their results. This is an alternative
+// to stdin and stdout for clients that can't use them, like clients that
+// run in a browser. The client repeatedly polls for the next test case,
+// with a GET request, and posts the result of each, with a POST request.
+//
+// To the rest of the test runner, it looks like a client process that
+// uses stdin and stdout: the control channel relays the requests written
+// to the "process" to the client and relays the client's results back.
+type controlChannel struct {
+	url           string
+	command       []string
+	allowedOrigin string
+	errPrinter    internal.Printer
+	server        *http.Server
+	pollTimeout   time.Duration
+
+	// Test cases to send to the client. This is closed when there are
+	// no more.
+	requests chan *conformancev1.ClientCompatRequest
+	// Results received from the client.
+	results chan *conformancev1.ClientCompatResponse
+	// Closed once the client first contacts the control channel.
+	connected     chan struct{}
+	connectedOnce sync.Once
+	// Closed once all results have been relayed.
+	done chan struct{}
+
+	mu sync.Mutex
+	// The state of each test case that was taken from requests, by name.
+	// Results are only accepted for test cases that were delivered to the
+	// client, and only once.
+	testCases map[string]controlChannelTestCaseState
+}
+
+type controlChannelTestCaseState int
+
+const (
+	// The test case was sent to the client, and its result is expected.
+	testCaseDelivered = controlChannelTestCaseState(iota + 1)
+	// The test case could not be sent to the client, and was reported
+	// as failed.
+	testCaseUndelivered
+	// The client reported the result of the test case.
+	testCaseReported
+)
+
+// newControlChannel starts serving a control channel at the given address.
+// If the given command is not empty, it is started when the client process
+// is started, with the URL of the control channel in its environment.
+// Otherwise, the client under test must be started separately and pointed
+// at the control channel's URL. The command's output is printed to the
+// given printer.
+//
+// If allowedOrigin is not empty, the control channel allows cross-origin
+// requests from that origin, so it can be used by clients that run in a
+// browser. An allowed origin of "*" allows requests from any origin.
+func newControlChannel(addr string, allowedOrigin string, command []string, errPrinter internal.Printer) (*controlChannel, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for control channel: %w", err)
+	}
+	ctrl := &controlChannel{
+		url:           "http://" + listener.Addr().String(),
+		command:       command,
+		allowedOrigin: allowedOrigin,
+		errPrinter:    errPrinter,
+		pollTimeout:   defaultControlChannelPollTimeout,
+		requests:      make(chan *conformancev1.ClientCompatRequest),
+		results:       make(chan *conformancev1.ClientCompatResponse),
+		connected:     make(chan struct{}),
+		done:          make(chan struct{}),
+		testCases:     map[string]controlChannelTestCaseState{},
+	}
+	ctrl.server = &http.Server{
+		Handler:           ctrl,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = ctrl.server.Serve(listener)
+	}()
+	return ctrl, nil
+}
+
+func (c *controlChannel) String() string {
+	return "control channel at " + c.url
+}
+
+func (c *controlChannel) close() error {
+	return c.server.Close()
+}
+
+// start returns a process starter for the client under test. If there is
+// a client command, it is started. The returned starter then blocks until
+// the client first contacts the control channel.
+func (c *controlChannel) start() processStarter {
+	return func(ctx context.Context, pipeStderr bool) (*process, error) {
+		var cmdProc *process
+		var cmdDone chan struct{}
+		if len(c.command) > 0 {
+			var err error
+			cmdProc, err = runCommandWithEnv(c.command, []string{controlChannelURLEnvVar + "=" + c.url})(ctx, false)
+			if err != nil {
+				return nil, err
+			}
+			// The client's output is not part of the protocol, so just show it.
+			_ = cmdProc.stdin.Close()
+			go func() {
+				r := bufio.NewReader(cmdProc.stdout)
+				for {
+					line, err := r.ReadString('\n')
+					if strings.TrimSpace(line) != "" {
+						c.errPrinter.PrefixPrintf("client", "%s", strings.TrimSuffix(line, "\n"))
+					}
+					if err != nil {
+						return
+					}
+				}
+			}()
+			cmdDone = make(chan struct{})
+			cmdProc.whenDone(func(error) {
+				close(cmdDone)
+			})
+		}
+		select {
+		case <-c.connected:
+		case <-cmdDone:
+			return nil, clientExitedError("connecting to "+c.String(), cmdProc)
+		case <-ctx.Done():
+			if cmdProc != nil {
+				cmdProc.abort()
+			}
+			return nil, ctx.Err()
+		}
+		return runInProcess([]string{"control-channel", c.url}, func(ctx context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error {
+			return c.relay(ctx, in, out, cmdProc, cmdDone)
+		})(ctx, pipeStderr)
+	}
+}
+
+// relay reads test cases from in, makes them available to the client, and
+// writes the client's results to out. It returns once the input has been
+// exhausted and a result has been received for every test case that was
+// sent to the client.
+func (c *controlChannel) relay(ctx context.Context, in io.Reader, out io.Writer, cmdProc *process, cmdDone chan struct{}) error {
+	defer close(c.done)
+	var outstanding atomic.Int64
+	inputDone := make(chan struct{})
+	go func() {
+		defer close(inputDone)
+		defer close(c.requests)
+		for {
+			req := &conformancev1.ClientCompatRequest{}
+			if err := internal.ReadDelimitedMessage(in, req); err != nil {
+				return
+			}
+			outstanding.Add(1)
+			select {
+			case c.requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	inputClosed := false
+	for !inputClosed || outstanding.Load() > 0 {
+		select {
+		case resp := <-c.results:
+			if err := internal.WriteDelimitedMessage(out, resp); err != nil {
+				return err
+			}
+			outstanding.Add(-1)
+		case <-inputDone:
+			inputClosed = true
+			inputDone = nil
+		case <-cmdDone:
+			return clientExitedError("reporting all results", cmdProc)
+		case <-ctx.Done():
+			if cmdProc != nil {
+				cmdProc.abort()
+			}
+			return nil
+		}
+	}
+
+	if cmdProc == nil {
+		return nil
+	}
+	// Give the client a chance to notice that there are no more test cases.
+	select {
+	case <-cmdDone:
+	case <-time.After(gracefulShutdownPeriod):
+		cmdProc.abort()
+	}
+	return cmdProc.result()
+}
+
+// clientExitedError returns an error that indicates that the client command
+// exited before it should have.
+func clientExitedError(before string, cmdProc *process) error {
+	if err := cmdProc.result(); err != nil {
+		return fmt.Errorf("client exited before %s: %w", before, err)
+	}
+	return fmt.Errorf("client exited before %s", before)
+}
+
+func (c *controlChannel) ServeHTTP(respWriter http.ResponseWriter, req *http.Request) {
+	if c.allowedOrigin != "" {
+		// Allow clients that run in a browser to connect from the allowed origin.
+		respWriter.Header().Set("Access-Control-Allow-Origin", c.allowedOrigin)
+		if c.allowedOrigin != "*" {
+			respWriter.Header().Add("Vary", "Origin")
+		}
+	}
+	if req.Method == http.MethodOptions && c.allowedOrigin != "" {
+		respWriter.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		respWriter.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type")
+		respWriter.WriteHeader(http.StatusNoContent)
+		return
+	}
+	var allowedMethod string
+	switch req.URL.Path {
+	case controlChannelNextPath:
+		allowedMethod = http.MethodGet
+	case controlChannelResultPath:
+		allowedMethod = http.MethodPost
+	default:
+		http.NotFound(respWriter, req)
+		return
+	}
+	if req.Method != allowedMethod {
+		respWriter.Header().Set("Allow", allowedMethod)
+		http.Error(respWriter, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.connectedOnce.Do(func() {
+		close(c.connected)
+	})
+	if req.URL.Path == controlChannelNextPath {
+		c.serveNext(respWriter, req)
+	} else {
+		c.serveResult(respWriter, req)
+	}
+}
+
+// serveNext responds with the next test case. If none is available before
+// the poll timeout, it responds with "204 No Content", and the client should
+// ask again. Once there are no more test cases, it responds with "410 Gone",
+// and the client should exit.
+func (c *controlChannel) serveNext(respWriter http.ResponseWriter, req *http.Request) {
+	timer := time.NewTimer(c.pollTimeout)
+	defer timer.Stop()
+	select {
+	case testCase, ok := <-c.requests:
+		if !ok {
+			http.Error(respWriter, "no more test cases", http.StatusGone)
+			return
+		}
+		c.mu.Lock()
+		c.testCases[testCase.TestName] = testCaseDelivered
+		c.mu.Unlock()
+		var data []byte
+		var err error
+		contentType := "application/proto"
+		if acceptsJSON(req.Header.Get("Accept")) {
+			contentType = "application/json"
+			data, err = protojson.Marshal(testCase)
+		} else {
+			data, err = proto.Marshal(testCase)
+		}
+		if err != nil {
+			http.Error(respWriter, err.Error(), http.StatusInternalServerError)
+			c.failUndelivered(testCase, err)
+			return
+		}
+		respWriter.Header().Set("Content-Type", contentType)
+		if _, err := respWriter.Write(data); err != nil {
+			c.failUndelivered(testCase, err)
+			return
+		}
+		if flusher, ok := respWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		// If the client disconnected, it may not have received the test case.
+		if err := req.Context().Err(); err != nil {
+			c.failUndelivered(testCase, err)
+		}
+	case <-timer.C:
+		respWriter.WriteHeader(http.StatusNoContent)
+	case <-req.Context().Done():
+	}
+}
+
+// failUndelivered reports a failed result for a test case that was taken
+// from c.requests but could not be delivered to the client. Otherwise, the
+// relay would wait forever for its result. If the client managed to report
+// a result anyway, that result is kept.
+func (c *controlChannel) failUndelivered(testCase *conformancev1.ClientCompatRequest, err error) {
+	c.mu.Lock()
+	if c.testCases[testCase.TestName] == testCaseReported {
+		c.mu.Unlock()
+		return
+	}
+	c.testCases[testCase.TestName] = testCaseUndelivered
+	c.mu.Unlock()
+	result := &conformancev1.ClientCompatResponse{
+		TestName: testCase.TestName,
+		Result: &conformancev1.ClientCompatResponse_Error{
+			Error: &conformancev1.ClientErrorResult{
+				Message: fmt.Sprintf("failed to deliver test case to client via control channel: %v", err),
+			},
+		},
+	}
+	select {
+	case c.results <- result:
+	case <-c.done:
+	}
+}
+
+// serveResult accepts the result of a test case. A result is only accepted
+// once for each test case that was delivered to the client.
+func (c *controlChannel) serveResult(respWriter http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(respWriter, req.Body, maxControlChannelResultSize))
+	if err != nil {
+		http.Error(respWriter, fmt.Sprintf("failed to read result: %v", err), http.StatusBadRequest)
+		return
+	}
+	var result conformancev1.ClientCompatResponse
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		err = protojson.Unmarshal(data, &result)
+	case "", "application/proto", "application/x-protobuf":
+		err = proto.Unmarshal(data, &result)
+	default:
+		http.Error(respWriter, fmt.Sprintf("unsupported content type %q", mediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(respWriter, fmt.Sprintf("failed to unmarshal result: %v", err), http.StatusBadRequest)
+		return
+	}
+	if result.TestName == "" {
+		http.Error(respWriter, "result is missing test name", http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	state, ok := c.testCases[result.TestName]
+	if state == testCaseDelivered {
+		c.testCases[result.TestName] = testCaseReported
+	}
+	c.mu.Unlock()
+	switch {
+	case !ok:
+		http.Error(respWriter, fmt.Sprintf("unknown test case %q", result.TestName), http.StatusBadRequest)
+		return
+	case state == testCaseUndelivered:
+		// The test case was already reported as failed.
+		http.Error(respWriter, "test case was not delivered successfully", http.StatusConflict)
+		return
+	case state == testCaseReported:
+		http.Error(respWriter, "result for test case was already reported", http.StatusConflict)
+		return
+	}
+	select {
+	case c.results <- &result:
+		respWriter.WriteHeader(http.StatusNoContent)
+	case <-c.done:
+		http.Error(respWriter, "no more results expected", http.StatusGone)
+	case <-req.Context().Done():
+		// The result was not relayed, so the client may post it again.
+		c.mu.Lock()
+		c.testCases[result.TestName] = testCaseDelivered
+		c.mu.Unlock()
+	}
+}
+
+func acceptsJSON(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err == nil && mediaType == "application/json" {
+			return true
+		}
+	}
+	return false
+}
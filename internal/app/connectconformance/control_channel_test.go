@@ -0,0 +1,248 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestControlChannel(t *testing.T) {
+	t.Parallel()
+	for _, useJSON := range []bool{false, true} {
+		useJSON := useJSON
+		t.Run(fmt.Sprintf("json:%v", useJSON), func(t *testing.T) {
+			t.Parallel()
+			ctrl, err := newControlChannel("127.0.0.1:0", "", nil, &internal.SimplePrinter{})
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = ctrl.close()
+			})
+			ctrl.pollTimeout = 100 * time.Millisecond
+
+			clientErr := make(chan error, 1)
+			go func() {
+				clientErr <- runControlChannelClient(ctrl.url, useJSON)
+			}()
+			runner, err := runClient(context.Background(), ctrl.start(), newStdioCodec(conformancev1.StdioFormat_STDIO_FORMAT_BINARY))
+			require.NoError(t, err)
+
+			names := []string{"foo/1", "foo/2", "foo/3"}
+			results := make(chan string, len(names))
+			// Wait for a poll to time out before sending anything.
+			time.Sleep(2 * ctrl.pollTimeout)
+			for _, name := range names {
+				err := runner.sendRequest(&conformancev1.ClientCompatRequest{TestName: name}, func(name string, resp *conformancev1.ClientCompatResponse, err error) {
+					if assert.NoError(t, err) {
+						assert.Equal(t, name, resp.GetResponse().GetResponseHeaders()[0].GetName())
+					}
+					results <- name
+				})
+				require.NoError(t, err)
+			}
+			runner.closeSend()
+			require.NoError(t, runner.waitForResponses())
+			require.NoError(t, <-clientErr)
+			close(results)
+			var actual []string
+			for name := range results {
+				actual = append(actual, name)
+			}
+			assert.ElementsMatch(t, names, actual)
+
+			// A result is only accepted once, and only for a test case that was sent.
+			resp, err := http.Post(ctrl.url+controlChannelResultPath, "application/json", bytes.NewReader([]byte(`{"testName":"foo/1"}`))) //nolint:noctx
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusConflict, resp.StatusCode)
+			resp, err = http.Post(ctrl.url+controlChannelResultPath, "application/json", bytes.NewReader([]byte(`{"testName":"foo/4"}`))) //nolint:noctx
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestControlChannel_HTTPErrors(t *testing.T) {
+	t.Parallel()
+	ctrl, err := newControlChannel("127.0.0.1:0", "", nil, &internal.SimplePrinter{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ctrl.close()
+	})
+
+	testCases := []struct {
+		method, path, contentType, body string
+		expectStatus                    int
+	}{
+		{method: http.MethodOptions, path: controlChannelNextPath, expectStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/foo", expectStatus: http.StatusNotFound},
+		{method: http.MethodPost, path: controlChannelNextPath, expectStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: controlChannelResultPath, expectStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodPost, path: controlChannelResultPath, contentType: "text/plain", body: "foo", expectStatus: http.StatusUnsupportedMediaType},
+		{method: http.MethodPost, path: controlChannelResultPath, contentType: "application/json", body: "{", expectStatus: http.StatusBadRequest},
+		{method: http.MethodPost, path: controlChannelResultPath, contentType: "application/json", body: "{}", expectStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		req, err := http.NewRequestWithContext(context.Background(), testCase.method, ctrl.url+testCase.path, bytes.NewReader([]byte(testCase.body)))
+		require.NoError(t, err)
+		if testCase.contentType != "" {
+			req.Header.Set("Content-Type", testCase.contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, testCase.expectStatus, resp.StatusCode, "%s %s", testCase.method, testCase.path)
+		// Cross-origin requests are not allowed unless an origin is configured.
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestControlChannel_AllowedOrigin(t *testing.T) {
+	t.Parallel()
+	for _, origin := range []string{"*", "http://localhost:3000"} {
+		ctrl, err := newControlChannel("127.0.0.1:0", origin, nil, &internal.SimplePrinter{})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = ctrl.close()
+		})
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodOptions, ctrl.url+controlChannelNextPath, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "http://localhost:3000")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", resp.Header.Get("Access-Control-Allow-Methods"))
+	}
+}
+
+func TestControlChannel_Undelivered(t *testing.T) {
+	t.Parallel()
+	ctrl, err := newControlChannel("127.0.0.1:0", "", nil, &internal.SimplePrinter{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ctrl.close()
+	})
+	ctrl.connectedOnce.Do(func() {
+		close(ctrl.connected)
+	})
+	runner, err := runClient(context.Background(), ctrl.start(), newStdioCodec(conformancev1.StdioFormat_STDIO_FORMAT_BINARY))
+	require.NoError(t, err)
+
+	result := make(chan *conformancev1.ClientCompatResponse, 1)
+	err = runner.sendRequest(&conformancev1.ClientCompatRequest{TestName: "foo/1"}, func(_ string, resp *conformancev1.ClientCompatResponse, err error) {
+		assert.NoError(t, err)
+		result <- resp
+	})
+	require.NoError(t, err)
+	runner.closeSend()
+	// The client disconnects while the test case is being written.
+	ctrl.serveNext(failingResponseWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, controlChannelNextPath, nil))
+	require.NoError(t, runner.waitForResponses())
+	assert.Contains(t, (<-result).GetError().GetMessage(), "failed to deliver test case to client")
+
+	// A late result for the test case is not accepted.
+	resp, err := http.Post(ctrl.url+controlChannelResultPath, "application/json", bytes.NewReader([]byte(`{"testName":"foo/1"}`))) //nolint:noctx
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+type failingResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+// runControlChannelClient acts like a client under test that uses the control
+// channel at the given URL. For each test case, it reports a result that echoes
+// the test case name in a response header.
+func runControlChannelClient(url string, useJSON bool) error {
+	for {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url+controlChannelNextPath, nil)
+		if err != nil {
+			return err
+		}
+		if useJSON {
+			req.Header.Set("Accept", "application/json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNoContent:
+			continue
+		case http.StatusGone:
+			return nil
+		default:
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, data)
+		}
+		var testCase conformancev1.ClientCompatRequest
+		unmarshal, marshal, contentType := proto.Unmarshal, proto.Marshal, "application/proto"
+		if useJSON {
+			unmarshal, marshal, contentType = protojson.Unmarshal, protojson.Marshal, "application/json"
+		}
+		if resp.Header.Get("Content-Type") != contentType {
+			return fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
+		}
+		if err := unmarshal(data, &testCase); err != nil {
+			return err
+		}
+		data, err = marshal(&conformancev1.ClientCompatResponse{
+			TestName: testCase.TestName,
+			Result: &conformancev1.ClientCompatResponse_Response{
+				Response: &conformancev1.ClientResponseResult{
+					ResponseHeaders: []*conformancev1.Header{{Name: testCase.TestName}},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		resp, err = http.Post(url+controlChannelResultPath, contentType, bytes.NewReader(data)) //nolint:noctx
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("unexpected status %d posting result", resp.StatusCode)
+		}
+	}
+}
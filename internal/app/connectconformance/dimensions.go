@@ -0,0 +1,187 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DimensionFlags narrow the config cases that are tested to those with
+// particular values for their dimensions. Each field may have multiple
+// values, in which case a config case must match any one of them. A field
+// with no values does not narrow the config cases.
+//
+// Enum values may be given as the full name of the enum value, like
+// "PROTOCOL_GRPC_WEB", or without the prefix, like "grpc_web". Matching
+// is case-insensitive, and dashes are treated like underscores, so
+// "grpc-web" also works.
+type DimensionFlags struct {
+	Protocols    []string
+	HTTPVersions []string
+	Codecs       []string
+	Compressions []string
+	StreamTypes  []string
+	// Values must be "true" or "false".
+	TLS []string
+}
+
+// empty returns true if none of the flags are set, in which case config
+// cases are not filtered.
+func (d DimensionFlags) empty() bool {
+	return len(d.Protocols) == 0 && len(d.HTTPVersions) == 0 && len(d.Codecs) == 0 &&
+		len(d.Compressions) == 0 && len(d.StreamTypes) == 0 && len(d.TLS) == 0
+}
+
+// configCaseFilter is the parsed form of DimensionFlags.
+type configCaseFilter struct {
+	versions     []conformancev1.HTTPVersion
+	protocols    []conformancev1.Protocol
+	codecs       []conformancev1.Codec
+	compressions []conformancev1.Compression
+	streamTypes  []conformancev1.StreamType
+	tls          []bool
+}
+
+// newConfigCaseFilter parses the given flags. It returns nil if none of
+// the flags have values.
+func newConfigCaseFilter(flags DimensionFlags) (*configCaseFilter, error) {
+	var filter configCaseFilter
+	var err error
+	if filter.protocols, err = parseEnumValues[conformancev1.Protocol]("protocol", flags.Protocols); err != nil {
+		return nil, err
+	}
+	if filter.versions, err = parseEnumValues[conformancev1.HTTPVersion]("HTTP version", flags.HTTPVersions); err != nil {
+		return nil, err
+	}
+	if filter.codecs, err = parseEnumValues[conformancev1.Codec]("codec", flags.Codecs); err != nil {
+		return nil, err
+	}
+	if filter.compressions, err = parseEnumValues[conformancev1.Compression]("compression", flags.Compressions); err != nil {
+		return nil, err
+	}
+	if filter.streamTypes, err = parseEnumValues[conformancev1.StreamType]("stream type", flags.StreamTypes); err != nil {
+		return nil, err
+	}
+	for _, val := range flags.TLS {
+		useTLS, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS value %q: must be true or false", val)
+		}
+		filter.tls = append(filter.tls, useTLS)
+	}
+	if len(filter.protocols) == 0 && len(filter.versions) == 0 && len(filter.codecs) == 0 &&
+		len(filter.compressions) == 0 && len(filter.streamTypes) == 0 && len(filter.tls) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	return &filter, nil
+}
+
+// accept returns true if the given config case matches the filter.
+func (f *configCaseFilter) accept(cfgCase configCase) bool {
+	return matchesAny(f.versions, cfgCase.Version) &&
+		matchesAny(f.protocols, cfgCase.Protocol) &&
+		matchesAny(f.codecs, cfgCase.Codec) &&
+		matchesAny(f.compressions, cfgCase.Compression) &&
+		matchesAny(f.streamTypes, cfgCase.StreamType) &&
+		matchesAny(f.tls, cfgCase.UseTLS)
+}
+
+// apply returns the subset of the given config cases that match the
+// filter. It returns an error if none of them match.
+func (f *configCaseFilter) apply(configCases []configCase) ([]configCase, error) {
+	if f == nil {
+		return configCases, nil
+	}
+	filtered := make([]configCase, 0, len(configCases))
+	for _, cfgCase := range configCases {
+		if f.accept(cfgCase) {
+			filtered = append(filtered, cfgCase)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("none of the %d config case permutations match the given dimension filters", len(configCases))
+	}
+	return filtered, nil
+}
+
+// loadFilteredConfig is like loadConfig, but narrows the resulting config
+// cases using the given dimension flags.
+func loadFilteredConfig(fileName string, selfReported *selfReportedConfig, dimensions DimensionFlags, verbose bool, logPrinter internal.Printer) ([]configCase, error) {
+	filter, err := newConfigCaseFilter(dimensions)
+	if err != nil {
+		return nil, err
+	}
+	configCases, err := loadConfig(fileName, selfReported, verbose, logPrinter)
+	if err != nil {
+		return nil, err
+	}
+	configCases, err = filter.apply(configCases)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil && verbose {
+		logPrinter.Printf("Filtered to %d config case permutations using dimension filters.", len(configCases))
+	}
+	return configCases, nil
+}
+
+func matchesAny[T comparable](values []T, find T) bool {
+	return len(values) == 0 || contains(values, find)
+}
+
+type int32Enum interface {
+	~int32
+	protoreflect.Enum
+}
+
+// parseEnumValues parses the given strings into values of the enum E. The
+// strings may omit the common prefix of the enum's value names, which is
+// derived from the name of its zero value. So "grpc" is the same as
+// "PROTOCOL_GRPC". The zero value itself is not allowed.
+func parseEnumValues[E int32Enum](what string, values []string) ([]E, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var zero E
+	enumValues := zero.Descriptor().Values()
+	prefix := strings.TrimSuffix(string(enumValues.ByNumber(0).Name()), "UNSPECIFIED")
+	results := make([]E, 0, len(values))
+	for _, val := range values {
+		name := strings.ToUpper(strings.ReplaceAll(val, "-", "_"))
+		if !strings.HasPrefix(name, prefix) {
+			name = prefix + name
+		}
+		enumVal := enumValues.ByName(protoreflect.Name(name))
+		if enumVal == nil || enumVal.Number() == 0 {
+			validNames := make([]string, 0, enumValues.Len()-1)
+			for i := 0; i < enumValues.Len(); i++ {
+				if enumValues.Get(i).Number() == 0 {
+					continue
+				}
+				validName := strings.TrimPrefix(string(enumValues.Get(i).Name()), prefix)
+				validNames = append(validNames, strings.ToLower(strings.ReplaceAll(validName, "_", "-")))
+			}
+			return nil, fmt.Errorf("invalid %s %q: must be one of %s", what, val, strings.Join(validNames, ", "))
+		}
+		results = append(results, E(enumVal.Number()))
+	}
+	return results, nil
+}
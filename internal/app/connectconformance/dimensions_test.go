@@ -0,0 +1,133 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnumValues(t *testing.T) {
+	t.Parallel()
+	protocols, err := parseEnumValues[conformancev1.Protocol]("protocol", []string{"connect", "PROTOCOL_GRPC", "gRPC-Web"})
+	require.NoError(t, err)
+	assert.Equal(t, []conformancev1.Protocol{
+		conformancev1.Protocol_PROTOCOL_CONNECT,
+		conformancev1.Protocol_PROTOCOL_GRPC,
+		conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+	}, protocols)
+
+	versions, err := parseEnumValues[conformancev1.HTTPVersion]("HTTP version", []string{"2", "http_version_3"})
+	require.NoError(t, err)
+	assert.Equal(t, []conformancev1.HTTPVersion{
+		conformancev1.HTTPVersion_HTTP_VERSION_2,
+		conformancev1.HTTPVersion_HTTP_VERSION_3,
+	}, versions)
+
+	streamTypes, err := parseEnumValues[conformancev1.StreamType]("stream type", nil)
+	require.NoError(t, err)
+	assert.Empty(t, streamTypes)
+
+	_, err = parseEnumValues[conformancev1.Codec]("codec", []string{"proto", "yaml"})
+	require.EqualError(t, err, `invalid codec "yaml": must be one of proto, json, text`)
+	_, err = parseEnumValues[conformancev1.Codec]("codec", []string{"unspecified"})
+	require.EqualError(t, err, `invalid codec "unspecified": must be one of proto, json, text`)
+}
+
+func TestConfigCaseFilter(t *testing.T) {
+	t.Parallel()
+	configCases := []configCase{
+		{
+			Version:    conformancev1.HTTPVersion_HTTP_VERSION_1,
+			Protocol:   conformancev1.Protocol_PROTOCOL_CONNECT,
+			Codec:      conformancev1.Codec_CODEC_JSON,
+			StreamType: conformancev1.StreamType_STREAM_TYPE_UNARY,
+		},
+		{
+			Version:    conformancev1.HTTPVersion_HTTP_VERSION_2,
+			Protocol:   conformancev1.Protocol_PROTOCOL_GRPC,
+			Codec:      conformancev1.Codec_CODEC_PROTO,
+			StreamType: conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+			UseTLS:     true,
+		},
+		{
+			Version:    conformancev1.HTTPVersion_HTTP_VERSION_2,
+			Protocol:   conformancev1.Protocol_PROTOCOL_CONNECT,
+			Codec:      conformancev1.Codec_CODEC_PROTO,
+			StreamType: conformancev1.StreamType_STREAM_TYPE_UNARY,
+		},
+	}
+	testCases := []struct {
+		name        string
+		flags       DimensionFlags
+		expected    []configCase
+		expectedErr string
+	}{
+		{
+			name:     "no filters",
+			expected: configCases,
+		},
+		{
+			name:     "single value",
+			flags:    DimensionFlags{Protocols: []string{"connect"}},
+			expected: []configCase{configCases[0], configCases[2]},
+		},
+		{
+			name:     "multiple values",
+			flags:    DimensionFlags{Codecs: []string{"json", "proto"}, StreamTypes: []string{"server-stream", "unary"}},
+			expected: configCases,
+		},
+		{
+			name:     "multiple dimensions",
+			flags:    DimensionFlags{HTTPVersions: []string{"2"}, TLS: []string{"false"}},
+			expected: []configCase{configCases[2]},
+		},
+		{
+			name:        "no matches",
+			flags:       DimensionFlags{Protocols: []string{"grpc-web"}},
+			expectedErr: "none of the 3 config case permutations match the given dimension filters",
+		},
+		{
+			name:        "invalid TLS value",
+			flags:       DimensionFlags{TLS: []string{"maybe"}},
+			expectedErr: `invalid TLS value "maybe": must be true or false`,
+		},
+		{
+			name:        "invalid compression",
+			flags:       DimensionFlags{Compressions: []string{"lz4"}},
+			expectedErr: `invalid compression "lz4": must be one of identity, gzip, br, zstd, deflate, snappy`,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			filter, err := newConfigCaseFilter(testCase.flags)
+			if err == nil {
+				var filtered []configCase
+				filtered, err = filter.apply(configCases)
+				if testCase.expectedErr == "" {
+					require.NoError(t, err)
+					assert.Equal(t, testCase.expected, filtered)
+					return
+				}
+			}
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
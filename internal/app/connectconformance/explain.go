@@ -0,0 +1,200 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// ExplainFlags are the flags that control what is explained by Explain.
+type ExplainFlags struct {
+	// The mode of the test run: "client", "server", or "both".
+	Mode       string
+	ConfigFile string
+	TestFiles  []string
+	// Patterns that match the names of suites or of test cases. Test case
+	// names are the suite name followed by the test case name as defined in
+	// the suite, such as "Basic/unary/success".
+	Patterns []string
+}
+
+// Explain prints, for each suite or test case that matches the given patterns,
+// whether each of the config's cases is included or excluded. If a config case
+// is excluded, the feature or suite constraint that excluded it is printed.
+func Explain(flags *ExplainFlags, printer internal.Printer) error {
+	useReferenceClient, useReferenceServer, err := referenceImplsForMode(flags.Mode)
+	if err != nil {
+		return err
+	}
+	patterns := parsePatterns(flags.Patterns)
+	if patterns == nil {
+		return errors.New("at least one suite or test case pattern is required")
+	}
+	var configData []byte
+	if flags.ConfigFile != "" {
+		if configData, err = os.ReadFile(flags.ConfigFile); err != nil {
+			return internal.EnsureFileName(err, flags.ConfigFile)
+		}
+	}
+	configCases, excluded, err := resolveConfig(flags.ConfigFile, configData)
+	if err != nil {
+		return err
+	}
+	allCases := make([]configCase, 0, len(configCases)+len(excluded))
+	for cfgCase := range configCases {
+		allCases = append(allCases, cfgCase)
+	}
+	for cfgCase := range excluded {
+		allCases = append(allCases, cfgCase)
+	}
+	sortConfigCases(allCases)
+
+	allSuites, err := loadTestSuites(flags.TestFiles, false, printer)
+	if err != nil {
+		return err
+	}
+	suites := make([]*conformancev1.TestSuite, 0, len(allSuites))
+	for _, suite := range allSuites {
+		suites = append(suites, suite)
+	}
+	sort.Slice(suites, func(i, j int) bool {
+		return suites[i].Name < suites[j].Name
+	})
+
+	mode := testMode(useReferenceClient, useReferenceServer)
+	explain := func(what string, suite *conformancev1.TestSuite, testCase *conformancev1.TestCase) {
+		printer.Printf("%s:", what)
+		var numIncluded int
+		for _, cfgCase := range allCases {
+			var reasons []string
+			if index, ok := excluded[cfgCase]; ok {
+				reasons = append(reasons, fmt.Sprintf("excluded by exclude_cases #%d in config", index))
+			}
+			reasons = append(reasons, explainExclusion(mode, suite, testCase, cfgCase)...)
+			if len(reasons) == 0 {
+				numIncluded++
+				printer.Printf("  %s: included", configCaseDescription(cfgCase))
+				continue
+			}
+			printer.Printf("  %s: excluded: %s", configCaseDescription(cfgCase), strings.Join(reasons, "; "))
+		}
+		printer.Printf("%d of %d config case(s) included.", numIncluded, len(allCases))
+	}
+	for _, suite := range suites {
+		if patterns.matchPattern(suite.Name) {
+			explain("Suite "+suite.Name, suite, nil)
+			continue
+		}
+		for _, testCase := range suite.TestCases {
+			name := suite.Name + "/" + testCase.Request.GetTestName()
+			if patterns.matchPattern(name) {
+				explain("Test case "+name, suite, testCase)
+			}
+		}
+	}
+
+	unmatched := patterns.allUnmatched()
+	if len(unmatched) == 0 {
+		return nil
+	}
+	unmatchedSlice := make([]string, 0, len(unmatched))
+	for name := range unmatched {
+		unmatchedSlice = append(unmatchedSlice, name)
+	}
+	sort.Strings(unmatchedSlice)
+	return fmt.Errorf("patterns do not match any suite or test case:\n%v", strings.Join(unmatchedSlice, "\n"))
+}
+
+// explainExclusion returns the reasons why the given config case is not used
+// for the given suite. If testCase is not nil, it also considers reasons that
+// are specific to that test case in the suite. If the returned slice is empty,
+// the config case is used.
+func explainExclusion(
+	mode conformancev1.TestSuite_TestMode,
+	suite *conformancev1.TestSuite,
+	testCase *conformancev1.TestCase,
+	cfgCase configCase,
+) []string {
+	var reasons []string
+	// In "both" mode (unspecified here), suites for other modes are run
+	// against a reference implementation, so they are not excluded.
+	if mode != conformancev1.TestSuite_TEST_MODE_UNSPECIFIED &&
+		suite.Mode != conformancev1.TestSuite_TEST_MODE_UNSPECIFIED && suite.Mode != mode {
+		reasons = append(reasons, fmt.Sprintf("suite is only for %s", suite.Mode))
+	}
+	reasons = append(reasons, suiteExclusionReasons(suite, cfgCase)...)
+	if testCase != nil {
+		if testCase.Request.StreamType != cfgCase.StreamType {
+			reasons = append(reasons, fmt.Sprintf("test case's stream type is %s", testCase.Request.StreamType))
+		}
+	} else {
+		var found bool
+		for _, testCase := range suite.TestCases {
+			if testCase.Request.StreamType == cfgCase.StreamType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			reasons = append(reasons, fmt.Sprintf("suite has no test cases with stream type %s", cfgCase.StreamType))
+		}
+	}
+	return reasons
+}
+
+func configCaseDescription(cfgCase configCase) string {
+	desc := fmt.Sprintf("{%s, %s, %s, %s, %s, TLS:%v, ClientCerts:%v, ConnectGET:%v, MessageReceiveLimit:%v",
+		cfgCase.Version, cfgCase.Protocol, cfgCase.Codec, cfgCase.Compression, cfgCase.StreamType,
+		cfgCase.UseTLS, cfgCase.UseTLSClientCerts, cfgCase.UseConnectGET, cfgCase.UseMessageReceiveLimit)
+	if cfgCase.ConnectVersionMode != conformancev1.TestSuite_CONNECT_VERSION_MODE_UNSPECIFIED {
+		desc += ", " + cfgCase.ConnectVersionMode.String()
+	}
+	return desc + "}"
+}
+
+func sortConfigCases(cases []configCase) {
+	sort.Slice(cases, func(i, j int) bool { //nolint:varnamelen
+		caseI, caseJ := cases[i], cases[j]
+		switch {
+		case caseI.Version != caseJ.Version:
+			return caseI.Version < caseJ.Version
+		case caseI.Protocol != caseJ.Protocol:
+			return caseI.Protocol < caseJ.Protocol
+		case caseI.Codec != caseJ.Codec:
+			return caseI.Codec < caseJ.Codec
+		case caseI.Compression != caseJ.Compression:
+			return caseI.Compression < caseJ.Compression
+		case caseI.StreamType != caseJ.StreamType:
+			return caseI.StreamType < caseJ.StreamType
+		case caseI.UseTLS != caseJ.UseTLS:
+			return !caseI.UseTLS
+		case caseI.UseTLSClientCerts != caseJ.UseTLSClientCerts:
+			return !caseI.UseTLSClientCerts
+		case caseI.UseConnectGET != caseJ.UseConnectGET:
+			return !caseI.UseConnectGET
+		case caseI.UseMessageReceiveLimit != caseJ.UseMessageReceiveLimit:
+			return !caseI.UseMessageReceiveLimit
+		default:
+			return caseI.ConnectVersionMode < caseJ.ConnectVersionMode
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configFile, []byte(`features:
+  versions: [HTTP_VERSION_1]
+  protocols: [PROTOCOL_CONNECT]
+  codecs: [CODEC_PROTO]
+  compressions: [COMPRESSION_IDENTITY, COMPRESSION_GZIP]
+  streamTypes: [STREAM_TYPE_UNARY, STREAM_TYPE_SERVER_STREAM]
+  supportsH2c: false
+  supportsTls: false
+  supportsConnectGet: false
+  supportsMessageReceiveLimit: false
+exclude_cases:
+- compression: COMPRESSION_GZIP
+  streamType: STREAM_TYPE_SERVER_STREAM
+`), 0600)
+	require.NoError(t, err)
+	testFile := filepath.Join(dir, "suite.yaml")
+	err = os.WriteFile(testFile, []byte(`name: Basic
+relevantCompressions: [COMPRESSION_IDENTITY]
+testCases:
+- request:
+    testName: unary/success
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+`), 0600)
+	require.NoError(t, err)
+
+	flags := &ExplainFlags{
+		Mode:       "client",
+		ConfigFile: configFile,
+		TestFiles:  []string{testFile},
+		Patterns:   []string{"Basic/unary/success"},
+	}
+	printer := &internal.SimplePrinter{}
+	require.NoError(t, Explain(flags, printer))
+	const settings = "TLS:false, ClientCerts:false, ConnectGET:false, MessageReceiveLimit:false}"
+	assert.Equal(t, []string{
+		"Test case Basic/unary/success:\n",
+		"  {HTTP_VERSION_1, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_IDENTITY, STREAM_TYPE_UNARY, " + settings + ": included\n",
+		"  {HTTP_VERSION_1, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_IDENTITY, STREAM_TYPE_SERVER_STREAM, " + settings +
+			": excluded: test case's stream type is STREAM_TYPE_UNARY\n",
+		"  {HTTP_VERSION_1, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_GZIP, STREAM_TYPE_UNARY, " + settings +
+			": excluded: COMPRESSION_GZIP not in suite's relevant_compressions\n",
+		"  {HTTP_VERSION_1, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_GZIP, STREAM_TYPE_SERVER_STREAM, " + settings +
+			": excluded: excluded by exclude_cases #1 in config; COMPRESSION_GZIP not in suite's relevant_compressions; test case's stream type is STREAM_TYPE_UNARY\n",
+		"1 of 4 config case(s) included.\n",
+	}, printer.Messages)
+
+	flags.Mode = "server"
+	flags.Patterns = []string{"Basic"}
+	printer = &internal.SimplePrinter{}
+	require.NoError(t, Explain(flags, printer))
+	require.Len(t, printer.Messages, 6)
+	assert.Equal(t, "Suite Basic:\n", printer.Messages[0])
+	assert.Equal(t, "  {HTTP_VERSION_1, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_IDENTITY, STREAM_TYPE_SERVER_STREAM, "+settings+
+		": excluded: suite has no test cases with stream type STREAM_TYPE_SERVER_STREAM\n", printer.Messages[2])
+
+	flags.Patterns = []string{"Basic/unary/failure"}
+	err = Explain(flags, &internal.SimplePrinter{})
+	require.ErrorContains(t, err, "patterns do not match any suite or test case:\nBasic/unary/failure")
+}
+
+func TestExplainExclusion_AgreesWithLibrary(t *testing.T) {
+	t.Parallel()
+	testSuiteData, err := testsuites.LoadTestSuites()
+	require.NoError(t, err)
+	configCases, err := parseConfig("config.yaml", []byte(`
+features:
+  supports_tls_client_certs: true
+  supports_half_duplex_bidi_over_http1: true
+`))
+	require.NoError(t, err)
+	// Also include a case that no config file can currently produce.
+	configCases = append(configCases, configCase{
+		Version:            conformancev1.HTTPVersion_HTTP_VERSION_1,
+		Protocol:           conformancev1.Protocol_PROTOCOL_CONNECT,
+		Codec:              conformancev1.Codec_CODEC_PROTO,
+		Compression:        conformancev1.Compression_COMPRESSION_IDENTITY,
+		StreamType:         conformancev1.StreamType_STREAM_TYPE_UNARY,
+		ConnectVersionMode: conformancev1.TestSuite_CONNECT_VERSION_MODE_REQUIRE,
+	})
+
+	for _, mode := range []conformancev1.TestSuite_TestMode{
+		conformancev1.TestSuite_TEST_MODE_CLIENT,
+		conformancev1.TestSuite_TEST_MODE_SERVER,
+	} {
+		mode := mode
+		t.Run(mode.String(), func(t *testing.T) {
+			t.Parallel()
+			allSuites, err := parseTestSuites(testSuiteData)
+			require.NoError(t, err)
+			lib, err := newTestCaseLibrary(allSuites, configCases, mode)
+			require.NoError(t, err)
+			included := map[string]struct{}{}
+			for _, testCase := range lib.allPermutations(false, false) {
+				included[testCase.Request.TestName] = struct{}{}
+			}
+			// A test case name doesn't describe every property of a config
+			// case, so a permutation is explained as included if any of the
+			// config cases with its name are included.
+			explained := map[string]struct{}{}
+			for _, suite := range allSuites {
+				for _, cfgCase := range configCases {
+					prefix := generateTestCasePrefix(suite, cfgCase)
+					for _, testCase := range suite.TestCases {
+						if len(explainExclusion(mode, suite, testCase, cfgCase)) == 0 {
+							explained[path.Join(append(prefix, testCase.Request.GetTestName())...)] = struct{}{}
+						}
+					}
+				}
+			}
+			assert.Equal(t, included, explained)
+		})
+	}
+}
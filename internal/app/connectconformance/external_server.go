@@ -0,0 +1,144 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// ExternalServer describes an already-running server under test. When
+// configured, test cases are sent to this server, instead of starting
+// a server process for each server configuration.
+type ExternalServer struct {
+	Host string
+	Port uint32
+	// If non-empty, the server uses TLS, and this is the path to a
+	// PEM-encoded CA certificate with which to verify the server's
+	// certificate.
+	CACertFile string
+	// The protocols and HTTP versions that the server supports. These
+	// are parsed the same way as the corresponding DimensionFlags. If
+	// empty, the server is assumed to support all of them.
+	Protocols    []string
+	HTTPVersions []string
+}
+
+// externalServer is the parsed form of ExternalServer.
+type externalServer struct {
+	host         string
+	port         uint32
+	caCert       []byte
+	protocols    []conformancev1.Protocol
+	httpVersions []conformancev1.HTTPVersion
+}
+
+func newExternalServer(server *ExternalServer) (*externalServer, error) {
+	if server.Port == 0 {
+		return nil, errors.New("external server port must be specified")
+	}
+	ext := &externalServer{
+		host: server.Host,
+		port: server.Port,
+	}
+	if ext.host == "" {
+		ext.host = internal.DefaultHost
+	}
+	if server.CACertFile != "" {
+		caCert, err := os.ReadFile(server.CACertFile)
+		if err != nil {
+			return nil, internal.EnsureFileName(err, server.CACertFile)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%s: no PEM-encoded certificates found", server.CACertFile)
+		}
+		ext.caCert = caCert
+	}
+	var err error
+	if ext.protocols, err = parseEnumValues[conformancev1.Protocol]("protocol", server.Protocols); err != nil {
+		return nil, err
+	}
+	if ext.httpVersions, err = parseEnumValues[conformancev1.HTTPVersion]("HTTP version", server.HTTPVersions); err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+func (e *externalServer) String() string {
+	return "server at " + net.JoinHostPort(e.host, strconv.FormatUint(uint64(e.port), 10))
+}
+
+// accept returns true if the given config case can be tested with
+// the external server. Since the server was not started by the test
+// runner, it can't be configured to trust a client certificate or to
+// enforce a message size limit, so config cases that require those
+// are not accepted.
+func (e *externalServer) accept(cfgCase configCase) bool {
+	return matchesAny(e.protocols, cfgCase.Protocol) &&
+		matchesAny(e.httpVersions, cfgCase.Version) &&
+		cfgCase.UseTLS == (len(e.caCert) > 0) &&
+		!cfgCase.UseTLSClientCerts &&
+		!cfgCase.UseMessageReceiveLimit
+}
+
+// apply returns the subset of the given config cases that can be tested
+// with the external server. It returns an error if there are none.
+func (e *externalServer) apply(configCases []configCase) ([]configCase, error) {
+	filtered := make([]configCase, 0, len(configCases))
+	for _, cfgCase := range configCases {
+		if e.accept(cfgCase) {
+			filtered = append(filtered, cfgCase)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("none of the %d config case permutations are compatible with the %s", len(configCases), e)
+	}
+	return filtered, nil
+}
+
+// start returns a process starter that, instead of starting a server
+// process, responds to the server request with the address of the
+// external server. The "process" then runs until it is stopped.
+func (e *externalServer) start() processStarter {
+	return runInProcess([]string{"external-server"}, func(ctx context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error {
+		var req conformancev1.ServerCompatRequest
+		if err := internal.ReadDelimitedMessage(in, &req); err != nil {
+			return err
+		}
+		if !matchesAny(e.protocols, req.Protocol) || !matchesAny(e.httpVersions, req.HttpVersion) ||
+			req.UseTls != (len(e.caCert) > 0) || len(req.ClientTlsCert) > 0 {
+			return fmt.Errorf("%s does not support server config {%s, %s, TLS:%v}", e, req.HttpVersion, req.Protocol, req.UseTls)
+		}
+		err := internal.WriteDelimitedMessage(out, &conformancev1.ServerCompatResponse{
+			Host:    e.host,
+			Port:    e.port,
+			PemCert: e.caCert,
+		})
+		if err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return nil
+	})
+}
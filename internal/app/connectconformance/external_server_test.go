@@ -0,0 +1,101 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestExternalServer_Apply(t *testing.T) {
+	t.Parallel()
+	configCases := []configCase{
+		{Version: conformancev1.HTTPVersion_HTTP_VERSION_1, Protocol: conformancev1.Protocol_PROTOCOL_CONNECT},
+		{Version: conformancev1.HTTPVersion_HTTP_VERSION_2, Protocol: conformancev1.Protocol_PROTOCOL_CONNECT},
+		{Version: conformancev1.HTTPVersion_HTTP_VERSION_2, Protocol: conformancev1.Protocol_PROTOCOL_GRPC},
+		{Version: conformancev1.HTTPVersion_HTTP_VERSION_2, Protocol: conformancev1.Protocol_PROTOCOL_GRPC, UseTLS: true},
+		{Version: conformancev1.HTTPVersion_HTTP_VERSION_2, Protocol: conformancev1.Protocol_PROTOCOL_GRPC, UseMessageReceiveLimit: true},
+	}
+
+	ext, err := newExternalServer(&ExternalServer{Port: 8080, HTTPVersions: []string{"2"}})
+	require.NoError(t, err)
+	filtered, err := ext.apply(configCases)
+	require.NoError(t, err)
+	assert.Equal(t, []configCase{configCases[1], configCases[2]}, filtered)
+
+	ext, err = newExternalServer(&ExternalServer{Port: 8080, Protocols: []string{"connect"}, HTTPVersions: []string{"3"}})
+	require.NoError(t, err)
+	_, err = ext.apply(configCases)
+	require.ErrorContains(t, err, "none of the 5 config case permutations are compatible with the server at 127.0.0.1:8080")
+
+	_, err = newExternalServer(&ExternalServer{})
+	require.ErrorContains(t, err, "port must be specified")
+	_, err = newExternalServer(&ExternalServer{Port: 8080, Protocols: []string{"foo"}})
+	require.ErrorContains(t, err, `invalid protocol "foo"`)
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a cert"), 0600))
+	_, err = newExternalServer(&ExternalServer{Port: 8080, CACertFile: caFile})
+	require.ErrorContains(t, err, "no PEM-encoded certificates found")
+}
+
+func TestExternalServer_Start(t *testing.T) {
+	t.Parallel()
+	_, certBytes, err := internal.NewServerCert()
+	require.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, certBytes, 0600))
+	ext, err := newExternalServer(&ExternalServer{Host: "example.com", Port: 443, CACertFile: caFile})
+	require.NoError(t, err)
+
+	proc, err := ext.start()(context.Background(), false)
+	require.NoError(t, err)
+	err = internal.WriteDelimitedMessage(proc.stdin, &conformancev1.ServerCompatRequest{
+		Protocol:    conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+		HttpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+		UseTls:      true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, proc.stdin.Close())
+	var resp conformancev1.ServerCompatResponse
+	require.NoError(t, internal.ReadDelimitedMessage(proc.stdout, &resp))
+	assert.Empty(t, cmp.Diff(&conformancev1.ServerCompatResponse{
+		Host:    "example.com",
+		Port:    443,
+		PemCert: certBytes,
+	}, &resp, protocmp.Transform()))
+	// The process runs until stopped.
+	proc.abort()
+	require.NoError(t, proc.result())
+
+	// Incompatible server config
+	proc, err = ext.start()(context.Background(), false)
+	require.NoError(t, err)
+	err = internal.WriteDelimitedMessage(proc.stdin, &conformancev1.ServerCompatRequest{
+		Protocol:    conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+		HttpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, proc.stdin.Close())
+	require.ErrorContains(t, proc.result(), "server at example.com:443 does not support server config")
+}
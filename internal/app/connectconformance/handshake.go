@@ -0,0 +1,206 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// handshakeEnvVar is the name of the environment variable that asks the
+// implementation under test to report its own config. When it is set, the
+// implementation writes a Config message to stdout before processing any
+// requests.
+const handshakeEnvVar = "CONNECTCONFORMANCE_HANDSHAKE"
+
+// selfReportedConfig is a config that was reported by the implementation
+// under test during a handshake.
+type selfReportedConfig struct {
+	config *conformancev1.Config
+	// A description of the implementation that reported the config,
+	// like "client under test".
+	reporter string
+}
+
+// runHandshake starts the implementation under test that is described by
+// the given flags and returns the config that it reports.
+func runHandshake(ctx context.Context, flags *Flags) (*selfReportedConfig, error) {
+	clientStdioFormat, serverStdioFormat, err := loadStdioFormats(flags)
+	if err != nil {
+		return nil, err
+	}
+	var command []string
+	var reporter string
+	var stdioFormat conformancev1.StdioFormat
+	switch {
+	case flags.ClientControlAddr != "":
+		return nil, errors.New("handshake is not supported with a client control channel")
+	case flags.ExternalServer != nil:
+		return nil, errors.New("handshake is not supported with an already-running server")
+	case len(flags.ClientCommand) > 0 && len(flags.ServerCommand) > 0:
+		return nil, errors.New("handshake is not supported when testing both a client and a server")
+	case len(flags.ClientCommand) > 0:
+		command, reporter, stdioFormat = flags.ClientCommand, "client under test", clientStdioFormat
+	case len(flags.ServerCommand) > 0:
+		command, reporter, stdioFormat = flags.ServerCommand, "server under test", serverStdioFormat
+	default:
+		return nil, errors.New("handshake requires a command for the client or server under test")
+	}
+	start := runCommandWithEnv(command, []string{handshakeEnvVar + "=1"})
+	config, err := handshake(ctx, start, newStdioCodec(stdioFormat))
+	if err != nil {
+		return nil, fmt.Errorf("handshake with %s failed: %w", reporter, err)
+	}
+	return &selfReportedConfig{config: config, reporter: reporter}, nil
+}
+
+// handshake starts a process and reads the config that it writes to stdout.
+// The process's stdin is closed right away, which tells it that there are
+// no requests to process, so it should exit after writing the config.
+func handshake(ctx context.Context, start processStarter, codec internal.Codec) (*conformancev1.Config, error) {
+	proc, err := start(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	_ = proc.stdin.Close()
+	defer func() {
+		// Discard any other output, so the process doesn't block writing it.
+		go func() {
+			_, _ = io.Copy(io.Discard, proc.stdout)
+		}()
+		// The exit status doesn't matter: we already have the config.
+		done := make(chan struct{})
+		proc.whenDone(func(error) {
+			close(done)
+		})
+		select {
+		case <-done:
+		case <-time.After(gracefulShutdownPeriod):
+			proc.abort()
+			_ = proc.result()
+		}
+	}()
+
+	config := &conformancev1.Config{}
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- codec.NewDecoder(proc.stdout).DecodeNext(config)
+	}()
+	select {
+	case err := <-readErr:
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	case <-time.After(testCaseTimeout):
+		return nil, errors.New("timed out waiting for config")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return config, nil
+}
+
+// merge merges the self-reported config into the given config, which was
+// loaded from the named config file. Values in the config file take
+// precedence. When the config file and the self-reported config both set
+// a field but disagree on its value, a warning is printed.
+func (s *selfReportedConfig) merge(fileName string, fromFile *conformancev1.Config, logPrinter internal.Printer) *conformancev1.Config {
+	merged := proto.Clone(fromFile).(*conformancev1.Config)   //nolint:forcetypeassert
+	reported := proto.Clone(s.config).(*conformancev1.Config) //nolint:forcetypeassert
+	// The stdio format must be known before the handshake, so it can only
+	// come from the config file.
+	reported.StdioFormat = conformancev1.StdioFormat_STDIO_FORMAT_UNSPECIFIED
+	mergeConfigFields(merged.ProtoReflect(), reported.ProtoReflect(), "", func(path, fileValue, reportedValue string) {
+		logPrinter.Printf("WARNING: %s: %s is %s, but the %s reports %s; using the value from the config file.",
+			fileName, path, fileValue, s.reporter, reportedValue)
+	})
+	return merged
+}
+
+// mergeConfigFields copies the fields that are set in src, but not in dst,
+// into dst. Nested messages are merged recursively. For other fields that
+// are set in both, dst is left as is, and warn is called if the two differ.
+func mergeConfigFields(dst, src protoreflect.Message, prefix string, warn func(path, dstValue, srcValue string)) {
+	fields := src.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !src.Has(field) {
+			continue
+		}
+		path := prefix + string(field.Name())
+		switch {
+		case !dst.Has(field):
+			dst.Set(field, src.Get(field))
+		case field.Message() != nil && !field.IsList() && !field.IsMap():
+			mergeConfigFields(dst.Mutable(field).Message(), src.Get(field).Message(), path+".", warn)
+		case !configValuesEqual(field, dst.Get(field), src.Get(field)):
+			warn(path, formatConfigValue(field, dst.Get(field)), formatConfigValue(field, src.Get(field)))
+		}
+	}
+}
+
+func configValuesEqual(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	if field.IsList() && field.Message() != nil {
+		listA, listB := a.List(), b.List()
+		if listA.Len() != listB.Len() {
+			return false
+		}
+		for i := 0; i < listA.Len(); i++ {
+			if !proto.Equal(listA.Get(i).Message().Interface(), listB.Get(i).Message().Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	// Lists of scalars are compared without regard to order.
+	return formatConfigValue(field, a) == formatConfigValue(field, b)
+}
+
+// formatConfigValue formats the given value of the given field for display
+// in a warning message.
+func formatConfigValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if !field.IsList() {
+		return formatConfigScalar(field, value)
+	}
+	list := value.List()
+	if field.Message() != nil {
+		return fmt.Sprintf("%d item(s)", list.Len())
+	}
+	items := make([]string, list.Len())
+	for i := range items {
+		items[i] = formatConfigScalar(field, list.Get(i))
+	}
+	sort.Strings(items)
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+func formatConfigScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if field.Enum() != nil {
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return fmt.Sprintf("%d", value.Enum())
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
@@ -0,0 +1,143 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestHandshake(t *testing.T) {
+	t.Parallel()
+	reported := &conformancev1.Config{
+		Features: &conformancev1.Features{
+			Versions:    []conformancev1.HTTPVersion{conformancev1.HTTPVersion_HTTP_VERSION_2},
+			SupportsTls: proto.Bool(false),
+		},
+	}
+	for _, format := range []conformancev1.StdioFormat{conformancev1.StdioFormat_STDIO_FORMAT_BINARY, conformancev1.StdioFormat_STDIO_FORMAT_JSON} {
+		format := format
+		t.Run(format.String(), func(t *testing.T) {
+			t.Parallel()
+			codec := newStdioCodec(format)
+			start := runInProcess([]string{"testimpl"}, func(_ context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error {
+				if err := codec.NewEncoder(out).Encode(reported); err != nil {
+					return err
+				}
+				// There should be no requests.
+				_, err := in.Read(make([]byte, 1))
+				if !errors.Is(err, io.EOF) {
+					return errors.New("expected stdin to be closed")
+				}
+				return nil
+			})
+			config, err := handshake(context.Background(), start, codec)
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(reported, config, protocmp.Transform()))
+		})
+	}
+
+	t.Run("no config", func(t *testing.T) {
+		t.Parallel()
+		start := runInProcess([]string{"testimpl"}, func(_ context.Context, _ []string, _ io.ReadCloser, _, _ io.WriteCloser) error {
+			return nil
+		})
+		_, err := handshake(context.Background(), start, internal.NewCodec(false))
+		require.ErrorContains(t, err, "failed to read config")
+	})
+}
+
+func TestSelfReportedConfigMerge(t *testing.T) {
+	t.Parallel()
+	fromFile := &conformancev1.Config{
+		Features: &conformancev1.Features{
+			Versions: []conformancev1.HTTPVersion{
+				conformancev1.HTTPVersion_HTTP_VERSION_1,
+				conformancev1.HTTPVersion_HTTP_VERSION_2,
+			},
+			Protocols:   []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT},
+			SupportsTls: proto.Bool(true),
+		},
+		StdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_JSON,
+	}
+	selfReported := &selfReportedConfig{
+		config: &conformancev1.Config{
+			Features: &conformancev1.Features{
+				Versions: []conformancev1.HTTPVersion{
+					conformancev1.HTTPVersion_HTTP_VERSION_2,
+				},
+				Protocols:   []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT},
+				SupportsTls: proto.Bool(false),
+				SupportsH2C: proto.Bool(true),
+			},
+			ExcludeCases: []*conformancev1.ConfigCase{
+				{Protocol: conformancev1.Protocol_PROTOCOL_CONNECT},
+			},
+			StdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_BINARY,
+		},
+		reporter: "client under test",
+	}
+
+	printer := &internal.SimplePrinter{}
+	merged := selfReported.merge("config.yaml", fromFile, printer)
+	assert.Empty(t, cmp.Diff(&conformancev1.Config{
+		Features: &conformancev1.Features{
+			Versions: []conformancev1.HTTPVersion{
+				conformancev1.HTTPVersion_HTTP_VERSION_1,
+				conformancev1.HTTPVersion_HTTP_VERSION_2,
+			},
+			Protocols:   []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT},
+			SupportsH2C: proto.Bool(true),
+			SupportsTls: proto.Bool(true),
+		},
+		ExcludeCases: []*conformancev1.ConfigCase{
+			{Protocol: conformancev1.Protocol_PROTOCOL_CONNECT},
+		},
+		StdioFormat: conformancev1.StdioFormat_STDIO_FORMAT_JSON,
+	}, merged, protocmp.Transform()))
+	assert.Equal(t, []string{
+		"WARNING: config.yaml: features.versions is [HTTP_VERSION_1, HTTP_VERSION_2], but the client under test reports [HTTP_VERSION_2]; using the value from the config file.\n",
+		"WARNING: config.yaml: features.supports_tls is true, but the client under test reports false; using the value from the config file.\n",
+	}, printer.Messages)
+	// The inputs are not modified.
+	assert.Nil(t, fromFile.ExcludeCases)
+	assert.Nil(t, fromFile.Features.SupportsH2C)
+
+	// Without a config file, the self-reported config is used as is.
+	printer = &internal.SimplePrinter{}
+	selfReported.config.ExcludeCases = nil
+	configCases, err := parseConfigWithSelfReported("", nil, selfReported, printer)
+	require.NoError(t, err)
+	assert.Empty(t, printer.Messages)
+	expectedCases, err := parseConfig("", []byte(`
+features:
+  versions: [HTTP_VERSION_2]
+  protocols: [PROTOCOL_CONNECT]
+  supports_tls: false
+  supports_h2c: true
+`))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expectedCases, configCases)
+}
@@ -0,0 +1,161 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+const (
+	// These are inserted into test case permutation names in "both" mode,
+	// for the permutations of a test case that run the client or server
+	// under test against a reference implementation, instead of running
+	// them against each other.
+	referenceClientMarker = "(reference client)"
+	referenceServerMarker = "(reference server)"
+)
+
+// newInteropTestCaseLibrary creates the library of test cases for "both"
+// mode, where a client under test is run against a server under test. In
+// addition to the test cases that run the two against each other, this
+// includes test cases that run each against a reference implementation:
+// client-mode test cases run the client under test against the reference
+// server, and server-mode test cases run the reference client against the
+// server under test. The names of the latter include a marker that
+// identifies the reference implementation used.
+func newInteropTestCaseLibrary(
+	allSuites map[string]*conformancev1.TestSuite,
+	configCases []configCase,
+) (*testCaseLibrary, error) {
+	lib, err := newTestCaseLibrary(allSuites, configCases, conformancev1.TestSuite_TEST_MODE_UNSPECIFIED)
+	if err != nil {
+		return nil, err
+	}
+	pairings := []struct {
+		mode   conformancev1.TestSuite_TestMode
+		marker string
+	}{
+		{mode: conformancev1.TestSuite_TEST_MODE_CLIENT, marker: referenceServerMarker},
+		{mode: conformancev1.TestSuite_TEST_MODE_SERVER, marker: referenceClientMarker},
+	}
+	for _, pairing := range pairings {
+		modeLib, err := newTestCaseLibrary(allSuites, configCases, pairing.mode)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pairing.mode, err)
+		}
+		for fullName, testCase := range modeLib.testCases {
+			simpleName := modeLib.testCaseNames[fullName]
+			name := addMarkerToName(fullName, simpleName, pairing.marker)
+			testCase.Request.TestName = name
+			lib.testCases[name] = testCase
+			lib.testCaseNames[name] = simpleName
+		}
+	}
+	lib.groupTestCases()
+	return lib, nil
+}
+
+// interopPairing returns whether the named test case, in "both" mode, is run
+// using the reference client or the reference server. If both are false, the
+// test case runs the client under test against the server under test.
+func interopPairing(testCaseName string) (referenceClient, referenceServer bool) {
+	for _, component := range strings.Split(testCaseName, "/") {
+		switch component {
+		case referenceClientMarker:
+			referenceClient = true
+		case referenceServerMarker:
+			referenceServer = true
+		}
+	}
+	return referenceClient, referenceServer
+}
+
+// filterInteropTestCases returns the subset of the given test cases that, in
+// "both" mode, are run with the given pairing of client and server.
+func filterInteropTestCases(testCases []*conformancev1.TestCase, referenceClient, referenceServer bool) []*conformancev1.TestCase {
+	filtered := make([]*conformancev1.TestCase, 0, len(testCases))
+	for _, testCase := range testCases {
+		isReferenceClient, isReferenceServer := interopPairing(testCase.Request.TestName)
+		if isReferenceClient == referenceClient && isReferenceServer == referenceServer {
+			filtered = append(filtered, testCase)
+		}
+	}
+	return filtered
+}
+
+// reportInteropMatrix prints a matrix that shows, for each pairing of client
+// and server in "both" mode, whether its test cases passed.
+func (r *testResults) reportInteropMatrix(printer internal.Printer) {
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+	// Indexed by [isReferenceClient][isReferenceServer]
+	var total, failed [2][2]int
+	for name, outcome := range r.outcomes {
+		referenceClient, referenceServer := interopPairing(name)
+		row, col := boolIndex(referenceClient), boolIndex(referenceServer)
+		total[row][col]++
+		if outcome.status().isFailure() {
+			failed[row][col]++
+		}
+	}
+	cell := func(row, col int) string {
+		switch {
+		case total[row][col] == 0:
+			return "-"
+		case failed[row][col] == 0:
+			return fmt.Sprintf("PASS (%d test cases)", total[row][col])
+		default:
+			return fmt.Sprintf("FAIL (%d of %d failed)", failed[row][col], total[row][col])
+		}
+	}
+	table := [][]string{
+		{"", "server under test", "reference server"},
+		{"client under test", cell(0, 0), cell(0, 1)},
+		{"reference client", cell(1, 0), cell(1, 1)},
+	}
+	widths := make([]int, len(table[0]))
+	for _, row := range table {
+		for i, val := range row {
+			if len(val) > widths[i] {
+				widths[i] = len(val)
+			}
+		}
+	}
+	printer.Printf("\nInterop matrix:")
+	for _, row := range table {
+		var line strings.Builder
+		for i, val := range row {
+			if i < len(row)-1 {
+				_, _ = fmt.Fprintf(&line, "%-*s  ", widths[i], val)
+			} else {
+				line.WriteString(val)
+			}
+		}
+		printer.Printf("  %s", line.String())
+	}
+}
+
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
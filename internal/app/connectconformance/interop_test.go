@@ -0,0 +1,109 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInteropTestCaseLibrary(t *testing.T) {
+	t.Parallel()
+	allSuites, err := parseTestSuites(map[string][]byte{
+		"both.yaml": []byte(`
+name: Both
+testCases:
+  - request:
+      testName: unary
+      streamType: STREAM_TYPE_UNARY`),
+		"client.yaml": []byte(`
+name: Client
+mode: TEST_MODE_CLIENT
+testCases:
+  - request:
+      testName: unary
+      streamType: STREAM_TYPE_UNARY`),
+		"server.yaml": []byte(`
+name: Server
+mode: TEST_MODE_SERVER
+testCases:
+  - request:
+      testName: unary
+      streamType: STREAM_TYPE_UNARY`),
+	})
+	require.NoError(t, err)
+	configCases := []configCase{{
+		Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
+		Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+		Codec:       conformancev1.Codec_CODEC_PROTO,
+		Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+		StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+	}}
+	lib, err := newInteropTestCaseLibrary(allSuites, configCases)
+	require.NoError(t, err)
+
+	const prefix = "/HTTPVersion:2/Protocol:PROTOCOL_CONNECT/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/"
+	var names []string
+	for name := range lib.testCases {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"Both" + prefix + "unary",
+		"Both" + prefix + "(reference server)/unary",
+		"Client" + prefix + "(reference server)/unary",
+		"Both" + prefix + "(reference client)/unary",
+		"Server" + prefix + "(reference client)/unary",
+	}, names)
+	for name, testCase := range lib.testCases {
+		assert.Equal(t, name, testCase.Request.TestName)
+		assert.Equal(t, "unary", lib.testCaseNames[name])
+	}
+
+	testCases := lib.allPermutations(false, false)
+	require.Len(t, testCases, 5)
+	filtered := filterInteropTestCases(testCases, false, false)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "Both"+prefix+"unary", filtered[0].Request.TestName)
+	filtered = filterInteropTestCases(testCases, false, true)
+	assert.Len(t, filtered, 2)
+	filtered = filterInteropTestCases(testCases, true, false)
+	assert.Len(t, filtered, 2)
+	filtered = filterInteropTestCases(testCases, true, true)
+	assert.Empty(t, filtered)
+}
+
+func TestResults_InteropMatrix(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, nil)
+	results.setOutcome("foo/(reference server)/1", false, nil)
+	results.setOutcome("foo/(reference server)/2", false, errors.New("ruh roh"))
+	results.setOutcome("foo/(reference server)/3", false, nil)
+	results.setOutcome("foo/(reference client)/1", false, nil)
+	logger := &internal.SimplePrinter{}
+	results.reportInteropMatrix(logger)
+	assert.Equal(t, []string{
+		"\nInterop matrix:\n",
+		"                     server under test    reference server\n",
+		"  client under test  PASS (2 test cases)  FAIL (1 of 3 failed)\n",
+		"  reference client   PASS (1 test cases)  -\n",
+	}, logger.Messages)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// resultRecord is the JSON representation of a single test case outcome.
+// A JSON results file contains one of these per line.
+type resultRecord struct {
+	Name string `json:"name"`
+	testCaseDimensions
+	Tags         []string `json:"tags,omitempty"`
+	Status       string   `json:"status"`
+	SetupError   bool     `json:"setupError,omitempty"`
+	KnownFailing bool     `json:"knownFailing,omitempty"`
+	KnownFlaky   bool     `json:"knownFlaky,omitempty"`
+	Sideband     string   `json:"sideband,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	Attempts     int      `json:"attempts,omitempty"`
+	// Timing information is absent if the test case was never sent to the client.
+	SentAt         *time.Time `json:"sentAt,omitempty"`
+	ReceivedAt     *time.Time `json:"receivedAt,omitempty"`
+	DurationMillis float64    `json:"durationMillis,omitempty"`
+}
+
+// writeJSONReport writes the results to the named file in JSON-lines format.
+func (r *testResults) writeJSONReport(fileName string) error {
+	return writeFile(fileName, r.writeJSON)
+}
+
+// writeJSON writes the results to the given writer in JSON-lines format:
+// each line is a JSON object that describes the outcome of one test case
+// permutation. Lines are sorted by test case name.
+func (r *testResults) writeJSON(w io.Writer) error {
+	r.traceWaitGroup.Wait() // make sure all traces have been received
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+
+	enc := json.NewEncoder(w)
+	for _, name := range r.sortedNamesLocked() {
+		if err := enc.Encode(r.recordLocked(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *testResults) recordLocked(name string) *resultRecord {
+	outcome := r.outcomes[name]
+	record := &resultRecord{
+		Name:         name,
+		Status:       outcome.status().String(),
+		SetupError:   outcome.setupError,
+		KnownFailing: outcome.knownFailing,
+		KnownFlaky:   outcome.knownFlaky,
+		Sideband:     outcome.sideband,
+		Attempts:     r.attempts[name],
+	}
+	if outcome.actualFailure != nil {
+		record.Error = outcome.actualFailure.Error()
+	}
+	if timing, ok := r.timings[name]; ok {
+		record.SentAt = &timing.sent
+		record.ReceivedAt = &timing.received
+		record.DurationMillis = float64(timing.duration()) / float64(time.Millisecond)
+	}
+	if req := r.requests[name]; req != nil {
+		record.testCaseDimensions = dimensionsForRequest(req)
+	}
+	record.Tags = r.tags[name]
+	return record
+}
+
+// testCaseDimensions is the JSON representation of the configuration
+// of a test case permutation.
+type testCaseDimensions struct {
+	Protocol          string `json:"protocol,omitempty"`
+	HTTPVersion       string `json:"httpVersion,omitempty"`
+	Codec             string `json:"codec,omitempty"`
+	Compression       string `json:"compression,omitempty"`
+	StreamType        string `json:"streamType,omitempty"`
+	UseTLS            bool   `json:"useTls"`
+	UseTLSClientCerts bool   `json:"useTlsClientCerts"`
+}
+
+func dimensionsForRequest(req *conformancev1.ClientCompatRequest) testCaseDimensions {
+	return testCaseDimensions{
+		Protocol:          req.Protocol.String(),
+		HTTPVersion:       req.HttpVersion.String(),
+		Codec:             req.Codec.String(),
+		Compression:       req.Compression.String(),
+		StreamType:        req.StreamType.String(),
+		UseTLS:            len(req.ServerTlsCert) > 0,
+		UseTLSClientCerts: req.ClientTlsCreds != nil,
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_WriteJSON(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.addTestCases([]*conformancev1.TestCase{
+		{
+			Request: &conformancev1.ClientCompatRequest{
+				TestName:       "foo/bar/1",
+				Protocol:       conformancev1.Protocol_PROTOCOL_GRPC,
+				HttpVersion:    conformancev1.HTTPVersion_HTTP_VERSION_2,
+				Codec:          conformancev1.Codec_CODEC_PROTO,
+				Compression:    conformancev1.Compression_COMPRESSION_GZIP,
+				StreamType:     conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+				ServerTlsCert:  []byte("PLACEHOLDER"),
+				ClientTlsCreds: &conformancev1.ClientCompatRequest_TLSCreds{},
+			},
+			Tags: []string{"streaming", "tls"},
+		},
+	})
+	results.setOutcome("foo/bar/1", false, nil)
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/1", Message: "something awry"})
+	results.setOutcome("foo/bar/2", true, errors.New("ruh roh"))
+	results.setOutcome("known-to-fail/1", false, errors.New("fail"))
+	results.setOutcome("known-to-flake/1", false, nil)
+
+	var buf bytes.Buffer
+	err := results.writeJSON(&buf)
+	require.NoError(t, err)
+
+	var records []resultRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record resultRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []resultRecord{
+		{
+			Name: "foo/bar/1",
+			testCaseDimensions: testCaseDimensions{
+				Protocol:          "PROTOCOL_GRPC",
+				HTTPVersion:       "HTTP_VERSION_2",
+				Codec:             "CODEC_PROTO",
+				Compression:       "COMPRESSION_GZIP",
+				StreamType:        "STREAM_TYPE_SERVER_STREAM",
+				UseTLS:            true,
+				UseTLSClientCerts: true,
+			},
+			Tags:     []string{"streaming", "tls"},
+			Status:   "failed",
+			Sideband: "something awry",
+			Error:    "something awry",
+		},
+		{
+			Name:       "foo/bar/2",
+			Status:     "failed",
+			SetupError: true,
+			Error:      "ruh roh",
+		},
+		{
+			Name:         "known-to-fail/1",
+			Status:       "expected_failure",
+			KnownFailing: true,
+			Error:        "fail",
+		},
+		{
+			Name:       "known-to-flake/1",
+			Status:     "passed",
+			KnownFlaky: true,
+		},
+	}, records)
+}
+
+func TestResults_WriteJSONReport(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	fileName := filepath.Join(t.TempDir(), "results.json")
+	err := results.writeJSONReport(fileName)
+	require.NoError(t, err)
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"foo/bar/1","useTls":false,"useTlsClientCerts":false,"status":"passed"}`+"\n", string(data))
+}
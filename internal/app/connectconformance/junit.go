@@ -0,0 +1,215 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Error      *junitMessage    `xml:"error,omitempty"`
+	SystemOut  string           `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// writeJUnitReport writes the results as a JUnit XML report to the named file.
+func (r *testResults) writeJUnitReport(fileName string) error {
+	return writeFile(fileName, r.writeJUnit)
+}
+
+// writeFile creates the named file and uses the given function to write
+// its contents.
+func writeFile(fileName string, write func(io.Writer) error) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return internal.EnsureFileName(err, fileName)
+	}
+	if err := file.Close(); err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	return nil
+}
+
+// writeJUnit writes the results as a JUnit XML report to the given writer.
+// Each test case permutation becomes a <testcase> element, grouped into
+// <testsuite> elements by the name of the test suite that defines it.
+// Test cases that are known to fail or known to be flaky are reported as
+// skipped when they fail, instead of as failures. Test cases with only
+// warnings are reported as passing.
+func (r *testResults) writeJUnit(w io.Writer) error {
+	r.traceWaitGroup.Wait() // make sure all traces have been received
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+
+	report := junitTestSuites{Name: "connectconformance"}
+	var total time.Duration
+	suiteIndex := map[string]int{}
+	suiteDurations := map[string]time.Duration{}
+	for _, name := range r.sortedNamesLocked() {
+		outcome := r.outcomes[name]
+		suiteName, caseName, _ := strings.Cut(name, "/")
+		index, ok := suiteIndex[suiteName]
+		if !ok {
+			index = len(report.Suites)
+			suiteIndex[suiteName] = index
+			report.Suites = append(report.Suites, junitTestSuite{Name: suiteName})
+		}
+		suite := &report.Suites[index]
+		duration := r.timings[name].duration()
+		suiteDurations[suiteName] += duration
+		total += duration
+
+		testCase := junitTestCase{
+			Name:      caseName,
+			ClassName: suiteName,
+			Time:      junitSeconds(duration),
+		}
+		if tags := r.tags[name]; len(tags) > 0 {
+			// Tags are reported as properties, so failures can be grouped by tag.
+			testCase.Properties = &junitProperties{}
+			for _, tag := range tags {
+				testCase.Properties.Properties = append(testCase.Properties.Properties, junitProperty{Name: "tag", Value: tag})
+			}
+		}
+		switch outcome.status() {
+		case statusFailed:
+			message := junitMessage{Message: firstLine(outcome.actualFailure.Error())}
+			if outcome.setupError {
+				testCase.Error = &message
+				suite.Errors++
+			} else {
+				testCase.Failure = &message
+				suite.Failures++
+			}
+			testCase.SystemOut = r.junitOutputLocked(name, outcome.actualFailure.Error())
+		case statusUnexpectedPass:
+			testCase.Failure = &junitMessage{Message: "test case was expected to fail but did not"}
+			suite.Failures++
+		case statusExpectedFailure:
+			reason := "known failing"
+			if !outcome.knownFailing {
+				reason = "known flaky"
+			}
+			testCase.Skipped = &junitMessage{Message: fmt.Sprintf("failed as expected (%s)", reason)}
+			testCase.SystemOut = outcome.actualFailure.Error()
+			suite.Skipped++
+		case statusWarning:
+			// JUnit has no notion of warnings, so these are reported as passing,
+			// but with the warning in the output.
+			testCase.SystemOut = "WARNING: " + outcome.actualFailure.Error()
+		case statusPassed:
+		}
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	for i := range report.Suites {
+		suite := &report.Suites[i]
+		suite.Time = junitSeconds(suiteDurations[suite.Name])
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	report.Time = junitSeconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitOutputLocked returns the given failure message along with
+// the HTTP trace for the named test case, if one was captured.
+func (r *testResults) junitOutputLocked(name, failure string) string {
+	trace := r.traces[name]
+	if trace == nil {
+		return failure
+	}
+	var buf bytes.Buffer
+	printer := internal.NewPrinter(&buf)
+	printer.Printf("%s", failure)
+	printer.Printf("---- HTTP Trace ----")
+	trace.Print(printer)
+	printer.Printf("--------------------")
+	return buf.String()
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
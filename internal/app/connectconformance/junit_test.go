@@ -0,0 +1,149 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_WriteJUnit(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	start := time.Now()
+	results.recordTiming("foo/bar/1", start, start.Add(1500*time.Millisecond))
+	results.setOutcome("foo/bar/2", false, errors.New("ruh roh\nmore details"))
+	results.recordTiming("foo/bar/2", start, start.Add(250*time.Millisecond))
+	results.setOutcome("foo/baz", true, errors.New("could not start"))
+	results.setOutcome("known-to-fail/1", false, errors.New("fail"))
+	results.setOutcome("known-to-fail/2", false, nil)
+	results.setOutcome("known-to-flake/1", false, errors.New("flake"))
+	results.setOutcome("known-to-flake/2", false, nil)
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/1", Message: "something awry"})
+
+	var buf bytes.Buffer
+	err := results.writeJUnit(&buf)
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &report)
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Tests)
+	assert.Equal(t, 3, report.Failures)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, "1.750", report.Time)
+	require.Len(t, report.Suites, 3)
+
+	foo := report.Suites[0]
+	assert.Equal(t, "foo", foo.Name)
+	assert.Equal(t, 3, foo.Tests)
+	assert.Equal(t, 2, foo.Failures)
+	assert.Equal(t, 1, foo.Errors)
+	assert.Equal(t, "1.750", foo.Time)
+	require.Len(t, foo.TestCases, 3)
+	// Sideband info from server turns passing test into a failure.
+	assert.Equal(t, "bar/1", foo.TestCases[0].Name)
+	assert.Equal(t, "foo", foo.TestCases[0].ClassName)
+	assert.Equal(t, "1.500", foo.TestCases[0].Time)
+	require.NotNil(t, foo.TestCases[0].Failure)
+	assert.Equal(t, "something awry", foo.TestCases[0].Failure.Message)
+	assert.Equal(t, "bar/2", foo.TestCases[1].Name)
+	require.NotNil(t, foo.TestCases[1].Failure)
+	assert.Equal(t, "ruh roh", foo.TestCases[1].Failure.Message)
+	assert.Equal(t, "ruh roh\nmore details", foo.TestCases[1].SystemOut)
+	assert.Equal(t, "baz", foo.TestCases[2].Name)
+	assert.Nil(t, foo.TestCases[2].Failure)
+	require.NotNil(t, foo.TestCases[2].Error)
+	assert.Equal(t, "could not start", foo.TestCases[2].Error.Message)
+
+	knownFailing := report.Suites[1]
+	assert.Equal(t, "known-to-fail", knownFailing.Name)
+	require.Len(t, knownFailing.TestCases, 2)
+	require.NotNil(t, knownFailing.TestCases[0].Skipped)
+	assert.Equal(t, "fail", knownFailing.TestCases[0].SystemOut)
+	require.NotNil(t, knownFailing.TestCases[1].Failure)
+	assert.Equal(t, 1, knownFailing.Skipped)
+	assert.Equal(t, 1, knownFailing.Failures)
+
+	knownFlaky := report.Suites[2]
+	assert.Equal(t, "known-to-flake", knownFlaky.Name)
+	require.Len(t, knownFlaky.TestCases, 2)
+	require.NotNil(t, knownFlaky.TestCases[0].Skipped)
+	assert.Nil(t, knownFlaky.TestCases[1].Skipped)
+	assert.Nil(t, knownFlaky.TestCases[1].Failure)
+	assert.Equal(t, 1, knownFlaky.Skipped)
+	assert.Equal(t, 0, knownFlaky.Failures)
+
+	// Report should still see the same outcomes after writing JUnit.
+	logger := &internal.SimplePrinter{}
+	require.False(t, results.report(logger))
+	assert.Len(t, errorMessages(logger.Messages), 6)
+}
+
+func TestResults_WriteJUnitReport(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("ruh roh"))
+	fileName := filepath.Join(t.TempDir(), "results.xml")
+	err := results.writeJUnitReport(fileName)
+	require.NoError(t, err)
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	var report junitTestSuites
+	err = xml.Unmarshal(data, &report)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+
+	// Reports errors that name the file.
+	err = results.writeJUnitReport(filepath.Join(t.TempDir(), "missing", "results.xml"))
+	require.ErrorContains(t, err, "results.xml")
+}
+
+func TestResults_WriteJUnit_Tags(t *testing.T) {
+	t.Parallel()
+	results := newResults(&testTrie{}, &testTrie{}, nil)
+	results.addTestCases([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/bar"}, Tags: []string{"cancellation", "streaming"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/baz"}},
+	})
+	results.setOutcome("foo/bar", false, nil)
+	results.setOutcome("foo/baz", false, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, results.writeJUnit(&buf))
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Suites, 1)
+	require.Len(t, report.Suites[0].TestCases, 2)
+	assert.Equal(t, &junitProperties{Properties: []junitProperty{
+		{Name: "tag", Value: "cancellation"},
+		{Name: "tag", Value: "streaming"},
+	}}, report.Suites[0].TestCases[0].Properties)
+	assert.Nil(t, report.Suites[0].TestCases[1].Properties)
+}
@@ -0,0 +1,278 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// outcomeTree is a tree of test case names, where each node represents
+// a name component. It is used to compute a minimal set of patterns
+// that match all failing test cases.
+type outcomeTree struct {
+	children map[string]*outcomeTree
+	// true if this node corresponds to a test case
+	isTestCase bool
+	// true if the test case failed; only meaningful if isTestCase
+	failed bool
+	// number of test cases at or below this node
+	numTestCases int
+	// number of failed test cases at or below this node
+	numFailed int
+}
+
+func (t *outcomeTree) add(components []string, failed bool) {
+	t.numTestCases++
+	if failed {
+		t.numFailed++
+	}
+	if len(components) == 0 {
+		t.isTestCase = true
+		t.failed = failed
+		return
+	}
+	if t.children == nil {
+		t.children = map[string]*outcomeTree{}
+	}
+	child := t.children[components[0]]
+	if child == nil {
+		child = &outcomeTree{}
+		t.children[components[0]] = child
+	}
+	child.add(components[1:], failed)
+}
+
+// patterns returns the minimal set of patterns that match all failed
+// test cases in the tree, but no other test cases. If all test cases
+// in a sub-tree failed, and there is more than one, the sub-tree is
+// represented by a single pattern that ends in "**".
+func (t *outcomeTree) patterns(prefix string, patterns []string) []string {
+	if t.numFailed == 0 {
+		return patterns
+	}
+	if t.numFailed == t.numTestCases && t.numTestCases > 1 {
+		return append(patterns, joinPattern(prefix, "**"))
+	}
+	if t.isTestCase && t.failed {
+		patterns = append(patterns, prefix)
+	}
+	names := make([]string, 0, len(t.children))
+	for name := range t.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		patterns = t.children[name].patterns(joinPattern(prefix, name), patterns)
+	}
+	return patterns
+}
+
+func joinPattern(prefix, component string) string {
+	if prefix == "" {
+		return component
+	}
+	return prefix + "/" + component
+}
+
+// knownFailingPatternsLocked computes the minimal set of patterns that
+// match all test cases that are currently failing. Test cases that had
+// setup errors or only warnings are not included, nor are test cases that
+// are known to be flaky or that have any of the given tags (both of which
+// also prevent their sub-trees from being collapsed into a single wildcard
+// pattern).
+func (r *testResults) knownFailingPatternsLocked(excludeTags []string) []string {
+	var tree outcomeTree
+	for name, outcome := range r.outcomes {
+		failed := outcome.actualFailure != nil && !outcome.setupError && !outcome.knownFlaky && !outcome.warning &&
+			!hasAnyTag(r.tags[name], excludeTags)
+		tree.add(strings.Split(name, "/"), failed)
+	}
+	return tree.patterns("", nil)
+}
+
+// writeKnownFailing writes the patterns that match all test cases that are
+// currently failing to the named file, in a format that can be used with the
+// --known-failing flag. If the file already exists, its comment lines and
+// tag patterns are preserved in place, and test cases with those tags are
+// left out of the new patterns. See mergeKnownFailing.
+func (r *testResults) writeKnownFailing(fileName string) error {
+	var lines []string
+	existing, err := os.ReadFile(fileName)
+	switch {
+	case err == nil:
+		lines = knownFailingLines(existing)
+	case !errors.Is(err, fs.ErrNotExist):
+		return internal.EnsureFileName(err, fileName)
+	}
+
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	r.processSidebandInfoLocked()
+	_, tags := splitTagPatterns(lines)
+	patterns := r.knownFailingPatternsLocked(tags)
+	names := make([]string, 0, len(r.outcomes))
+	for name := range r.outcomes {
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+
+	lines = mergeKnownFailing(lines, patterns, names)
+	return writeFile(fileName, func(w io.Writer) error {
+		return writeKnownFailing(w, lines)
+	})
+}
+
+func writeKnownFailing(w io.Writer, lines []string) error {
+	bufWriter := bufio.NewWriter(w)
+	for _, line := range lines {
+		_, _ = bufWriter.WriteString(line)
+		_ = bufWriter.WriteByte('\n')
+	}
+	return bufWriter.Flush()
+}
+
+// knownFailingLines returns the lines of a known-failing file, with
+// leading and trailing whitespace removed.
+func knownFailingLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+	return lines
+}
+
+// mergeKnownFailing merges the given patterns into the lines of an existing
+// known-failing file. Comments, blank lines, and tag patterns are kept where
+// they are, but the other existing patterns are replaced. Each new pattern takes the place of the
+// first existing pattern that matched any of the same test cases, so that
+// comments stay with the patterns they explain. New patterns that match no
+// test cases from the existing patterns are added at the end.
+func mergeKnownFailing(lines, patterns, testCaseNames []string) []string {
+	// For each test case, find the first line that matched it.
+	firstLine := make(map[string]int, len(testCaseNames))
+	for i, line := range lines {
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, tagPatternPrefix) {
+			continue
+		}
+		trie := parsePatterns([]string{line})
+		for _, name := range testCaseNames {
+			if _, ok := firstLine[name]; !ok && trie.matchPattern(name) {
+				firstLine[name] = i
+			}
+		}
+	}
+	placed := map[int][]string{}
+	var remaining []string
+	for _, pattern := range patterns {
+		trie := parsePatterns([]string{pattern})
+		index := -1
+		for _, name := range testCaseNames {
+			if i, ok := firstLine[name]; ok && (index == -1 || i < index) && trie.matchPattern(name) {
+				index = i
+			}
+		}
+		if index == -1 {
+			remaining = append(remaining, pattern)
+			continue
+		}
+		placed[index] = append(placed[index], pattern)
+	}
+
+	var merged []string
+	for i, line := range lines {
+		switch {
+		case line == "":
+			// Avoid runs of blank lines where patterns were removed.
+			if len(merged) > 0 && merged[len(merged)-1] != "" {
+				merged = append(merged, line)
+			}
+		case strings.HasPrefix(line, "#"), strings.HasPrefix(line, tagPatternPrefix):
+			merged = append(merged, line)
+		default:
+			merged = append(merged, placed[i]...)
+		}
+	}
+	if len(remaining) > 0 && len(merged) > 0 && strings.HasPrefix(merged[len(merged)-1], "#") {
+		// Don't put new patterns under a comment about something else.
+		merged = append(merged, "")
+	}
+	merged = append(merged, remaining...)
+	for len(merged) > 0 && merged[len(merged)-1] == "" {
+		merged = merged[:len(merged)-1]
+	}
+	return merged
+}
+
+// staleKnownFailing returns the given known-failing patterns that matched
+// at least one test case, but for which none of the matched test cases
+// failed. Such patterns should be removed from the known-failing list.
+// Patterns that match no test cases that were run (which can happen when
+// using --run or --skip flags) are not considered stale. Patterns that name
+// a tag match the test cases with that tag.
+func (r *testResults) staleKnownFailing(patterns []string) []string {
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+
+	var stale []string
+	for _, pattern := range patterns {
+		trie := parsePatterns([]string{pattern})
+		tag, isTag := strings.CutPrefix(pattern, tagPatternPrefix)
+		var matched, failed bool
+		for name, outcome := range r.outcomes {
+			if isTag {
+				if !contains(r.tags[name], tag) {
+					continue
+				}
+			} else if !trie.matchPattern(name) {
+				continue
+			}
+			matched = true
+			if outcome.actualFailure != nil {
+				failed = true
+				break
+			}
+		}
+		if matched && !failed {
+			stale = append(stale, pattern)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// reportStaleKnownFailing prints any stale patterns in the given known-failing
+// patterns. See staleKnownFailing.
+func (r *testResults) reportStaleKnownFailing(printer internal.Printer, patterns []string) {
+	stale := r.staleKnownFailing(patterns)
+	if len(stale) == 0 {
+		return
+	}
+	printer.Printf("\nThe following %d known-failing pattern(s) no longer match any failing test cases and should be removed:", len(stale))
+	for _, pattern := range stale {
+		printer.Printf("  %s", pattern)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_KnownFailingPatterns(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	// whole suite fails
+	results.setOutcome("Suite A/HTTPVersion:1/case-1", false, errors.New("ruh roh"))
+	results.setOutcome("Suite A/HTTPVersion:1/case-2", false, errors.New("ruh roh"))
+	results.setOutcome("Suite A/HTTPVersion:2/case-1", false, errors.New("ruh roh"))
+	// only one sub-tree fails
+	results.setOutcome("Suite B/HTTPVersion:1/case-1", false, errors.New("ruh roh"))
+	results.setOutcome("Suite B/HTTPVersion:1/case-2", false, errors.New("ruh roh"))
+	results.setOutcome("Suite B/HTTPVersion:2/case-1", false, nil)
+	results.setOutcome("Suite B/HTTPVersion:2/case-2", false, errors.New("ruh roh"))
+	// setup errors are excluded
+	results.setOutcome("Suite C/case-1", true, errors.New("ruh roh"))
+	results.setOutcome("Suite C/case-2", false, errors.New("ruh roh"))
+	// flaky test cases are excluded and prevent collapsing sub-trees
+	results.setOutcome("known-to-flake/case-1", false, errors.New("ruh roh"))
+	results.setOutcome("known-to-flake/case-2", false, errors.New("ruh roh"))
+	// sideband info causes failures
+	results.setOutcome("Suite D/case-1", false, nil)
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "Suite D/case-1", Message: "something awry"})
+
+	var patterns []string
+	func() {
+		results.mu.Lock()
+		defer results.mu.Unlock()
+		results.processSidebandInfoLocked()
+		patterns = results.knownFailingPatternsLocked(nil)
+	}()
+	assert.Equal(t, []string{
+		"Suite A/**",
+		"Suite B/HTTPVersion:1/**",
+		"Suite B/HTTPVersion:2/case-2",
+		"Suite C/case-2",
+		"Suite D/case-1",
+	}, patterns)
+
+	// Computed patterns should match exactly the failing test cases.
+	trie := parsePatterns(patterns)
+	for name, outcome := range results.outcomes {
+		expectMatch := outcome.actualFailure != nil && !outcome.setupError && !outcome.knownFlaky
+		assert.Equal(t, expectMatch, trie.matchPattern(name), name)
+	}
+}
+
+func TestResults_WriteKnownFailing(t *testing.T) {
+	t.Parallel()
+	fileName := filepath.Join(t.TempDir(), "known-failing.txt")
+	err := os.WriteFile(fileName, []byte("# These are known to fail\n  # for reasons\nfoo/bar/1\n\nfoo/bar/2\n"), 0600)
+	require.NoError(t, err)
+
+	results := newResults(&testTrie{}, &testTrie{}, nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("ruh roh"))
+	results.setOutcome("foo/baz/1", false, errors.New("ruh roh"))
+	results.setOutcome("foo/baz/2", false, errors.New("ruh roh"))
+	err = results.writeKnownFailing(fileName)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "# These are known to fail\n# for reasons\n\nfoo/bar/2\nfoo/baz/**\n", string(data))
+}
+
+func TestResults_WriteKnownFailingKeepsTags(t *testing.T) {
+	t.Parallel()
+	fileName := filepath.Join(t.TempDir(), "known-failing.txt")
+	err := os.WriteFile(fileName, []byte("# Trailers are not supported\ntag:trailers\nfoo/3\n"), 0600)
+	require.NoError(t, err)
+
+	results := newResults(&testTrie{}, &testTrie{}, nil)
+	results.addTestCases([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/1"}, Tags: []string{"trailers"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/2"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/3"}},
+	})
+	results.setOutcome("foo/1", false, errors.New("ruh roh"))
+	results.setOutcome("foo/2", false, errors.New("ruh roh"))
+	results.setOutcome("foo/3", false, errors.New("ruh roh"))
+	err = results.writeKnownFailing(fileName)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	// The test case with the tag is not listed again, and its sibling
+	// test cases are not collapsed into a wildcard pattern.
+	assert.Equal(t, "# Trailers are not supported\ntag:trailers\nfoo/3\nfoo/2\n", string(data))
+}
+
+func TestResults_StaleKnownFailing(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("known-to-fail/1", false, errors.New("ruh roh"))
+	results.setOutcome("known-to-fail/2", false, nil)
+	results.setOutcome("known-to-fail/3/a", false, nil)
+	results.setOutcome("known-to-fail/3/b", false, nil)
+	stale := results.staleKnownFailing([]string{
+		"known-to-fail/**",   // not stale: one matching test case still fails
+		"known-to-fail/2",    // stale
+		"known-to-fail/3/*",  // stale
+		"known-to-fail/4/**", // not stale: matches nothing that was run
+	})
+	assert.Equal(t, []string{"known-to-fail/2", "known-to-fail/3/*"}, stale)
+}
+
+func TestResults_KnownFailingTags(t *testing.T) {
+	t.Parallel()
+	results := newResults(&testTrie{}, &testTrie{}, nil)
+	results.knownFailingTags = []string{"trailers"}
+	results.knownFlakyTags = []string{"timeouts"}
+	results.addTestCases([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/1"}, Tags: []string{"trailers"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/2"}, Tags: []string{"trailers"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "bar/1"}, Tags: []string{"timeouts"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "bar/2"}, Tags: []string{"other"}},
+	})
+	results.setOutcome("foo/1", false, errors.New("ruh roh"))
+	results.setOutcome("foo/2", false, errors.New("ruh roh"))
+	results.setOutcome("bar/1", false, errors.New("ruh roh"))
+	results.setOutcome("bar/2", false, nil)
+	for name, expected := range map[string]outcomeStatus{
+		"foo/1": statusExpectedFailure,
+		"foo/2": statusExpectedFailure,
+		"bar/1": statusExpectedFailure,
+		"bar/2": statusPassed,
+	} {
+		outcome := results.outcomes[name]
+		assert.Equal(t, expected, outcome.status(), name)
+	}
+
+	results.setOutcome("foo/2", false, nil)
+	stale := results.staleKnownFailing([]string{"tag:trailers", "tag:other", "tag:unused"})
+	// Only the tag whose test cases all pass is stale.
+	assert.Equal(t, []string{"tag:other"}, stale)
+}
+
+func TestMergeKnownFailing(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		"# Header",
+		"",
+		"# See issue 123",
+		"foo/bar/1",
+		"foo/bar/2",
+		"",
+		"# See issue 456",
+		"baz/1",
+		"",
+		"# See issue 789",
+		"qux/1",
+	}
+	names := []string{"foo/bar/1", "foo/bar/2", "baz/1", "baz/2", "qux/1", "new/1"}
+	merged := mergeKnownFailing(lines, []string{"baz/*", "foo/bar/**", "new/1"}, names)
+	assert.Equal(t, []string{
+		"# Header",
+		"",
+		"# See issue 123",
+		"foo/bar/**",
+		"",
+		"# See issue 456",
+		"baz/*",
+		"",
+		"# See issue 789",
+		"",
+		"new/1",
+	}, merged)
+}
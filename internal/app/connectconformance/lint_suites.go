@@ -0,0 +1,445 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"sort"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// lintProblem is a problem found in a test suite. The path identifies the
+// field that caused the problem, in the same form as for configError.
+type lintProblem struct {
+	path []any
+	msg  string
+}
+
+// LintSuites checks the test suites in the given files for mistakes that
+// would otherwise only be found at runtime, or not at all because they
+// cause test cases to silently never run. If no files are given, the
+// embedded test suites are checked. Any problems found are printed, along
+// with their location in the file. It returns true if no problems are found.
+// An error is returned if a file cannot be read.
+func LintSuites(testFiles []string, printer internal.Printer) (bool, error) {
+	var testSuiteData map[string][]byte
+	var err error
+	if len(testFiles) > 0 {
+		testSuiteData, err = testsuites.LoadTestSuitesFromFiles(testFiles)
+	} else {
+		testSuiteData, err = testsuites.LoadTestSuites()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load test suite data: %w", err)
+	}
+	fileNames := make([]string, 0, len(testSuiteData))
+	for fileName := range testSuiteData {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	suiteDesc := (&conformancev1.TestSuite{}).ProtoReflect().Descriptor()
+	suiteFiles := map[string]string{}
+	var numProblems int
+	for _, fileName := range fileNames {
+		suite, root, err := unmarshalTestSuite(fileName, testSuiteData[fileName])
+		if err != nil {
+			for _, err := range splitJoinedErrors(err) {
+				printer.Printf("%v", err)
+				numProblems++
+			}
+			continue
+		}
+		problems := lintSuite(suite)
+		if otherFile, ok := suiteFiles[suite.Name]; ok && suite.Name != "" {
+			problems = append(problems, lintProblem{
+				path: []any{"name"},
+				msg:  fmt.Sprintf("duplicate suite name %q (also defined in %s)", suite.Name, otherFile),
+			})
+		} else {
+			suiteFiles[suite.Name] = fileName
+		}
+		for _, problem := range problems {
+			if node := findYAMLNode(root, suiteDesc, problem.path); node != nil {
+				printer.Printf("%s:%d:%d %s", fileName, node.Line, node.Column, problem.msg)
+			} else {
+				printer.Printf("%s: %s", fileName, problem.msg)
+			}
+		}
+		numProblems += len(problems)
+	}
+	if numProblems > 0 {
+		printer.Printf("Found %d problem(s) in %d test suite file(s).", numProblems, len(fileNames))
+		return false, nil
+	}
+	printer.Printf("No problems found in %d test suite file(s).", len(fileNames))
+	return true, nil
+}
+
+// lintSuite returns the problems found in the given suite.
+func lintSuite(suite *conformancev1.TestSuite) []lintProblem {
+	var problems []lintProblem
+	report := func(msg string, path ...any) {
+		problems = append(problems, lintProblem{path: path, msg: msg})
+	}
+	if suite.Name == "" {
+		report("suite has no name")
+	}
+	if len(suite.TestCases) == 0 {
+		report("suite has no test cases")
+	}
+
+	// Check that the suite's relevant_* lists agree with its relies_on_* flags.
+	if suite.ReliesOnTlsClientCerts && !suite.ReliesOnTls {
+		report("suite sets relies_on_tls_client_certs but not relies_on_tls", "relies_on_tls_client_certs")
+	}
+	if suite.ReliesOnConnectGet && !only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
+		report("suite sets relies_on_connect_get, so relevant_protocols must be only PROTOCOL_CONNECT", "relies_on_connect_get")
+	}
+	if suite.ConnectVersionMode != conformancev1.TestSuite_CONNECT_VERSION_MODE_UNSPECIFIED &&
+		!only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
+		report("suite sets connect_version_mode, so relevant_protocols must be only PROTOCOL_CONNECT", "connect_version_mode")
+	}
+	if suite.ReliesOnMessageReceiveLimit && suite.Mode == conformancev1.TestSuite_TEST_MODE_UNSPECIFIED {
+		report("suite sets relies_on_message_receive_limit, so mode must indicate whether the client or server enforces the limit",
+			"relies_on_message_receive_limit")
+	}
+	if only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_GRPC) && len(suite.RelevantHttpVersions) > 0 &&
+		!contains(suite.RelevantHttpVersions, conformancev1.HTTPVersion_HTTP_VERSION_2) {
+		report("suite is only relevant to PROTOCOL_GRPC, which requires HTTP/2, but relevant_http_versions does not include HTTP_VERSION_2",
+			"relevant_http_versions")
+	}
+	lintUnspecified(suite.RelevantProtocols, "relevant_protocols", report)
+	lintUnspecified(suite.RelevantHttpVersions, "relevant_http_versions", report)
+	lintUnspecified(suite.RelevantCodecs, "relevant_codecs", report)
+	lintUnspecified(suite.RelevantCompressions, "relevant_compressions", report)
+
+	testNames := map[string]int{}
+	for i, testCase := range suite.TestCases {
+		casePath := []any{"test_cases", i}
+		reportCase := func(msg string, path ...any) {
+			report(msg, append(casePath[:2:2], path...)...)
+		}
+		if testCase.Request == nil {
+			reportCase("test case has no request")
+			continue
+		}
+		name := testCase.Request.TestName
+		if name == "" {
+			reportCase("test case has no name", "request")
+		} else if other, ok := testNames[name]; ok {
+			reportCase(fmt.Sprintf("duplicate test name %q (also used by test case #%d)", name, other+1), "request", "test_name")
+		} else {
+			testNames[name] = i
+		}
+		lintTestCase(suite, testCase, reportCase)
+	}
+	return problems
+}
+
+func lintUnspecified[T ~int32](values []T, field string, report func(msg string, path ...any)) {
+	for i, val := range values {
+		if val == 0 {
+			report(fmt.Sprintf("%s includes an unspecified value", field), field, i)
+		}
+	}
+}
+
+// lintTestCase reports problems in the given test case, which is in the
+// given suite. Paths given to report are relative to the test case.
+func lintTestCase( //nolint:gocyclo
+	suite *conformancev1.TestSuite,
+	testCase *conformancev1.TestCase,
+	report func(msg string, path ...any),
+) {
+	req := testCase.Request
+	if req.StreamType == conformancev1.StreamType_STREAM_TYPE_UNSPECIFIED {
+		report("test case has no stream type", "request")
+		return
+	}
+
+	// Check that the request messages agree with the stream type.
+	method := lintMethod(req, report)
+	if method != nil {
+		if !streamTypeMatches(req.StreamType, method) {
+			report(fmt.Sprintf("stream type %s does not match method %s", req.StreamType, method.Name()), "request", "stream_type")
+		}
+		for j, msg := range req.RequestMessages {
+			if msgName := msg.MessageName(); msgName != method.Input().FullName() {
+				report(fmt.Sprintf("request message has type %s but method %s requires %s", msgName, method.Name(), method.Input().FullName()),
+					"request", "request_messages", j)
+			}
+		}
+		if !method.IsStreamingClient() && len(req.RequestMessages) > 1 {
+			report(fmt.Sprintf("method %s accepts only one request message, but test case has %d", method.Name(), len(req.RequestMessages)),
+				"request", "request_messages")
+		}
+	}
+	msgs := make([]proto.Message, len(req.RequestMessages))
+	for j, msg := range req.RequestMessages {
+		var err error
+		if msgs[j], err = msg.UnmarshalNew(); err != nil {
+			report(fmt.Sprintf("request message could not be unmarshalled: %v", err), "request", "request_messages", j)
+			return
+		}
+	}
+	if len(msgs) > 0 {
+		if bidiReq, ok := msgs[0].(*conformancev1.BidiStreamRequest); ok {
+			switch {
+			case req.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM && !bidiReq.FullDuplex:
+				report("stream type is full-duplex, but the first request message does not set full_duplex", "request", "request_messages", 0)
+			case req.StreamType == conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM && bidiReq.FullDuplex:
+				report("stream type is half-duplex, but the first request message sets full_duplex", "request", "request_messages", 0)
+			}
+		}
+	}
+
+	// Check for raw requests and responses that have no effect in the suite's mode.
+	if req.RawRequest != nil && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		report("raw_request is only used when suite mode is TEST_MODE_SERVER", "request", "raw_request")
+	}
+	for j, msg := range msgs {
+		def := responseDefinition(msg)
+		if def == nil {
+			continue
+		}
+		if def.Has(def.Descriptor().Fields().ByName("raw_response")) {
+			switch {
+			case suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT:
+				report("raw_response is only used when suite mode is TEST_MODE_CLIENT", "request", "request_messages", j)
+			case j > 0:
+				report("raw_response is only used in the first request message", "request", "request_messages", j)
+			case testCase.ExpectedResponse == nil:
+				report("raw_response is used, so the test case must specify expected_response", "request", "request_messages", j)
+			}
+		}
+		if j > 0 && req.StreamType != conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM {
+			report("response definition is ignored because servers only use the definition in the first request message",
+				"request", "request_messages", j)
+		}
+		if errField := def.Descriptor().Fields().ByName("error"); def.Has(errField) {
+			if code := def.Get(errField).Message().Interface().(*conformancev1.Error).GetCode(); code == conformancev1.Code_CODE_UNSPECIFIED { //nolint:forcetypeassert
+				report("response definition has an error with no code", "request", "request_messages", j)
+			}
+		}
+	}
+
+	// Check that expand_requests directives line up with the request messages.
+	if len(testCase.ExpandRequests) > 0 { //nolint:nestif
+		if len(testCase.ExpandRequests) > len(req.RequestMessages) {
+			report(fmt.Sprintf("expand_requests has %d entries, but there are only %d request messages",
+				len(testCase.ExpandRequests), len(req.RequestMessages)), "expand_requests")
+		} else if err := expandRequestData(proto.Clone(testCase).(*conformancev1.TestCase)); err != nil { //nolint:forcetypeassert
+			report(fmt.Sprintf("expand_requests cannot be applied: %v", err), "expand_requests")
+		}
+		if len(suite.RelevantCodecs) != 1 || suite.RelevantCodecs[0] != conformancev1.Codec_CODEC_PROTO {
+			report("expand_requests is used, so the suite's relevant_codecs must be only CODEC_PROTO", "expand_requests")
+		}
+	}
+
+	// Check for expected responses that can never be satisfied.
+	lintExpectedResponse(testCase, msgs, report)
+	lintResponseMatchers(testCase, report)
+	lintExpectedResponseOverrides(suite, testCase, report)
+}
+
+// lintResponseMatchers reports problems with the given test case's response
+// matchers, including those that contradict its expected response.
+func lintResponseMatchers(testCase *conformancev1.TestCase, report func(msg string, path ...any)) {
+	if testCase.ResponseMatchers == nil {
+		return
+	}
+	if err := validateResponseMatchers(testCase.ResponseMatchers); err != nil {
+		for _, err := range splitJoinedErrors(err) {
+			report(fmt.Sprintf("invalid response matchers: %v", err), "response_matchers")
+		}
+	}
+	populated := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:forcetypeassert
+	if err := populateExpectedResponse(populated); err != nil {
+		return // already reported by lintExpectedResponse
+	}
+	if err := checkResponseMatchers(populated); err != nil {
+		for _, err := range splitJoinedErrors(err) {
+			report(fmt.Sprintf("invalid response matchers: %v", err), "response_matchers")
+		}
+	}
+}
+
+// lintExpectedResponseOverrides reports problems with the given test case's
+// expected response overrides, including overrides that can never apply
+// because they only match permutations that the suite excludes.
+func lintExpectedResponseOverrides(
+	suite *conformancev1.TestSuite,
+	testCase *conformancev1.TestCase,
+	report func(msg string, path ...any),
+) {
+	if len(testCase.ExpectedResponseOverrides) == 0 {
+		return
+	}
+	for i, override := range testCase.ExpectedResponseOverrides {
+		if err := validateExpectedResponseOverride(override); err != nil {
+			for _, err := range splitJoinedErrors(err) {
+				report(fmt.Sprintf("invalid expected response override: %v", err), "expected_response_overrides", i)
+			}
+			continue
+		}
+		switch {
+		case !overlaps(override.Protocols, suite.RelevantProtocols):
+			report("expected response override never applies: none of its protocols are relevant to the suite",
+				"expected_response_overrides", i, "protocols")
+		case !overlaps(override.HttpVersions, suite.RelevantHttpVersions):
+			report("expected response override never applies: none of its HTTP versions are relevant to the suite",
+				"expected_response_overrides", i, "http_versions")
+		case !overlaps(override.Codecs, suite.RelevantCodecs):
+			report("expected response override never applies: none of its codecs are relevant to the suite",
+				"expected_response_overrides", i, "codecs")
+		}
+		populated := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:forcetypeassert
+		if err := populateExpectedResponse(populated); err != nil {
+			return // already reported by lintExpectedResponse
+		}
+		populated.ExpectedResponseOverrides = populated.ExpectedResponseOverrides[i : i+1]
+		applyExpectedResponseOverrides(populated)
+		if err := checkResponseMatchers(populated); err != nil {
+			for _, err := range splitJoinedErrors(err) {
+				report(fmt.Sprintf("invalid response matchers after applying expected response override: %v", err),
+					"expected_response_overrides", i)
+			}
+		}
+	}
+}
+
+// overlaps returns true if the given criteria could match any of the given
+// relevant values. Empty criteria match everything, and empty relevant
+// values mean that all values are relevant.
+func overlaps[T comparable](criteria, relevant []T) bool {
+	if len(criteria) == 0 || len(relevant) == 0 {
+		return true
+	}
+	for _, val := range criteria {
+		if contains(relevant, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintMethod returns the method that the given request invokes. It returns
+// nil if the request is for a service other than the conformance service or
+// if the method cannot be determined, in which case a problem is reported.
+func lintMethod(req *conformancev1.ClientCompatRequest, report func(msg string, path ...any)) protoreflect.MethodDescriptor {
+	svcName, methodName := req.GetService(), req.GetMethod()
+	switch {
+	case svcName == "" && methodName != "":
+		report("test case specifies a method but no service", "request", "method")
+		return nil
+	case svcName != "" && methodName == "":
+		report("test case specifies a service but no method", "request", "service")
+		return nil
+	case svcName == "":
+		svcName = conformancev1connect.ConformanceServiceName
+		switch req.StreamType { //nolint:exhaustive
+		case conformancev1.StreamType_STREAM_TYPE_UNARY:
+			methodName = "Unary"
+		case conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM:
+			methodName = "ClientStream"
+		case conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM:
+			methodName = "ServerStream"
+		default:
+			methodName = "BidiStream"
+		}
+	}
+	if svcName != conformancev1connect.ConformanceServiceName {
+		return nil
+	}
+	svc := conformancev1.File_connectrpc_conformance_v1_service_proto.Services().ByName("ConformanceService")
+	method := svc.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		report(fmt.Sprintf("service %s has no method named %s", svcName, methodName), "request", "method")
+	}
+	return method
+}
+
+func streamTypeMatches(streamType conformancev1.StreamType, method protoreflect.MethodDescriptor) bool {
+	switch streamType { //nolint:exhaustive
+	case conformancev1.StreamType_STREAM_TYPE_UNARY:
+		return !method.IsStreamingClient() && !method.IsStreamingServer()
+	case conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM:
+		return method.IsStreamingClient() && !method.IsStreamingServer()
+	case conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM:
+		return !method.IsStreamingClient() && method.IsStreamingServer()
+	default:
+		return method.IsStreamingClient() && method.IsStreamingServer()
+	}
+}
+
+// responseDefinition returns the response definition in the given request
+// message, or nil if it has none.
+func responseDefinition(msg proto.Message) protoreflect.Message {
+	reflectMsg := msg.ProtoReflect()
+	field := reflectMsg.Descriptor().Fields().ByName("response_definition")
+	if field == nil || field.Message() == nil || !reflectMsg.Has(field) {
+		return nil
+	}
+	return reflectMsg.Get(field).Message()
+}
+
+// lintExpectedResponse reports problems that prevent the given test case's
+// expected response from ever being satisfied.
+func lintExpectedResponse(testCase *conformancev1.TestCase, msgs []proto.Message, report func(msg string, path ...any)) {
+	if testCase.ExpectedResponse != nil {
+		// An explicit expected response may describe anything, such as the
+		// result of a raw response, so there is nothing to check.
+		return
+	}
+	req := testCase.Request
+	if req.Cancel != nil {
+		report("request is cancelled, so the test case must specify expected_response", "request", "cancel")
+	}
+	if len(msgs) == 0 {
+		return
+	}
+	var numResponses int
+	var totalDelay uint64
+	if def := responseDefinition(msgs[0]); def != nil {
+		switch def := def.Interface().(type) {
+		case *conformancev1.UnaryResponseDefinition:
+			numResponses = 1
+			totalDelay = uint64(def.ResponseDelayMs)
+		case *conformancev1.StreamResponseDefinition:
+			numResponses = len(def.ResponseData)
+			totalDelay = uint64(def.ResponseDelayMs) * uint64(len(def.ResponseData))
+		}
+	}
+	totalDelay += uint64(req.RequestDelayMs) * uint64(len(msgs))
+	if req.TimeoutMs != nil && totalDelay >= uint64(req.GetTimeoutMs()) {
+		report(fmt.Sprintf("request and response delays (%dms) reach the timeout (%dms), so the test case must specify expected_response",
+			totalDelay, req.GetTimeoutMs()), "request", "timeout_ms")
+	}
+	if req.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM && numResponses > len(msgs) {
+		report(fmt.Sprintf("full-duplex stream defines %d responses but sends only %d requests, and the server sends one response per request",
+			numResponses, len(msgs)), "request", "request_messages")
+		return
+	}
+	if err := populateExpectedResponse(proto.Clone(testCase).(*conformancev1.TestCase)); err != nil { //nolint:forcetypeassert
+		report(fmt.Sprintf("expected response cannot be computed: %v", err), "request")
+	}
+}
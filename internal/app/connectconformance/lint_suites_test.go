@@ -0,0 +1,104 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintSuites_Embedded(t *testing.T) {
+	t.Parallel()
+	printer := &internal.SimplePrinter{}
+	ok, err := LintSuites(nil, printer)
+	require.NoError(t, err)
+	assert.True(t, ok, "%s", strings.Join(printer.Messages, ""))
+}
+
+func TestLintSuites(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	suiteFile := filepath.Join(dir, "suite.yaml")
+	err := os.WriteFile(suiteFile, []byte(`name: Lint
+mode: TEST_MODE_SERVER
+reliesOnConnectGet: true
+relevantCodecs: [CODEC_PROTO]
+testCases:
+- request:
+    testName: unary
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.ClientStreamRequest
+- request:
+    testName: unary
+    streamType: STREAM_TYPE_SERVER_STREAM
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest
+      responseDefinition:
+        rawResponse:
+          statusCode: 200
+- request:
+    testName: bidi
+    streamType: STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.BidiStreamRequest
+      fullDuplex: true
+      responseDefinition:
+        responseData: ["AA==", "AA==", "AA=="]
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.BidiStreamRequest
+      responseDefinition:
+        error:
+          message: oops
+  expandRequests:
+  - sizeRelativeToLimit: 0
+  - sizeRelativeToLimit: 0
+  - sizeRelativeToLimit: 0
+- request:
+    testName: overrides
+    streamType: STREAM_TYPE_UNARY
+  expectedResponseOverrides:
+  - codecs: [CODEC_JSON]
+  - clearFields: [foo]
+`), 0600)
+	require.NoError(t, err)
+
+	printer := &internal.SimplePrinter{}
+	ok, err := LintSuites([]string{suiteFile}, printer)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, []string{
+		suiteFile + ":3:1 suite sets relies_on_connect_get, so relevant_protocols must be only PROTOCOL_CONNECT\n",
+		suiteFile + ":10:7 request message has type connectrpc.conformance.v1.ClientStreamRequest but method Unary requires connectrpc.conformance.v1.UnaryRequest\n",
+		suiteFile + ":12:5 duplicate test name \"unary\" (also used by test case #1)\n",
+		suiteFile + ":15:7 raw_response is only used when suite mode is TEST_MODE_CLIENT\n",
+		suiteFile + ":27:7 response definition is ignored because servers only use the definition in the first request message\n",
+		suiteFile + ":27:7 response definition has an error with no code\n",
+		suiteFile + ":31:3 expand_requests has 3 entries, but there are only 2 request messages\n",
+		suiteFile + ":22:5 full-duplex stream defines 3 responses but sends only 2 requests, and the server sends one response per request\n",
+		suiteFile + ":39:5 expected response override never applies: none of its codecs are relevant to the suite\n",
+		suiteFile + ":40:5 invalid expected response override: must specify at least one protocol, HTTP version, or codec\n",
+		suiteFile + ":40:5 invalid expected response override: cannot clear unknown field \"foo\"\n",
+		"Found 11 problem(s) in 1 test suite file(s).\n",
+	}, printer.Messages)
+
+	_, err = LintSuites([]string{filepath.Join(dir, "missing.yaml")}, &internal.SimplePrinter{})
+	require.ErrorContains(t, err, "missing.yaml")
+}
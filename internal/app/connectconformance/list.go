@@ -0,0 +1,138 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"sort"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// ListFlags are the flags that control which test cases are listed
+// by List.
+type ListFlags struct {
+	// The mode of the test run: "client", "server", or "both".
+	Mode         string
+	ConfigFile   string
+	TestFiles    []string
+	RunPatterns  []string
+	SkipPatterns []string
+	Tags         []string
+	SkipTags     []string
+	Dimensions   DimensionFlags
+	// If true, each test case is printed as a JSON object that also
+	// describes its configuration and server instance.
+	JSON       bool
+	ShardIndex uint
+	ShardCount uint
+}
+
+// listRecord is the JSON representation of a test case permutation
+// printed by List.
+type listRecord struct {
+	Name string `json:"name"`
+	testCaseDimensions
+	Tags   []string   `json:"tags,omitempty"`
+	Server listServer `json:"server"`
+}
+
+// listServer is the JSON representation of the server instance
+// against which a test case permutation is run.
+type listServer struct {
+	Protocol          string `json:"protocol"`
+	HTTPVersion       string `json:"httpVersion"`
+	UseTLS            bool   `json:"useTls"`
+	UseTLSClientCerts bool   `json:"useTlsClientCerts"`
+}
+
+// List prints the names of the test case permutations that would be run
+// with the given flags, without actually running anything. The names are
+// printed one per line, in sorted order.
+func List(flags *ListFlags, printer internal.Printer) error {
+	useReferenceClient, useReferenceServer, err := referenceImplsForMode(flags.Mode)
+	if err != nil {
+		return err
+	}
+	configCases, err := loadFilteredConfig(flags.ConfigFile, nil, flags.Dimensions, false, printer)
+	if err != nil {
+		return err
+	}
+	allSuites, err := loadTestSuites(flags.TestFiles, false, printer)
+	if err != nil {
+		return err
+	}
+	testCaseLib, err := newTestCaseLibraryForRun(allSuites, configCases, useReferenceClient, useReferenceServer)
+	if err != nil {
+		return err
+	}
+	allPermutations := testCaseLib.allPermutations(useReferenceClient, useReferenceServer)
+
+	run := parsePatterns(flags.RunPatterns)
+	if run != nil {
+		if _, err := tryMatchPatterns("run patterns", run, allPermutations); err != nil {
+			return err
+		}
+	}
+	skip := parsePatterns(flags.SkipPatterns)
+	if skip != nil {
+		if _, err := tryMatchPatterns("no-run patterns", skip, allPermutations); err != nil {
+			return err
+		}
+	}
+	if len(flags.Tags) > 0 {
+		if _, err := tryMatchTags("run tags", flags.Tags, allPermutations); err != nil {
+			return err
+		}
+	}
+	if len(flags.SkipTags) > 0 {
+		if _, err := tryMatchTags("no-run tags", flags.SkipTags, allPermutations); err != nil {
+			return err
+		}
+	}
+	filter := newFilter(run, skip, flags.Tags, flags.SkipTags)
+	shard, err := newShard(testCaseLib, allPermutations, filter, flags.ShardIndex, flags.ShardCount)
+	if err != nil {
+		return err
+	}
+	testCases := shard.apply(filter.apply(allPermutations))
+	sort.Slice(testCases, func(i, j int) bool {
+		return testCases[i].Request.TestName < testCases[j].Request.TestName
+	})
+
+	for _, testCase := range testCases {
+		if !flags.JSON {
+			printer.Printf("%s", testCase.Request.TestName)
+			continue
+		}
+		svrInstance := serverInstanceForCase(testCase)
+		data, err := json.Marshal(&listRecord{
+			Name:               testCase.Request.TestName,
+			testCaseDimensions: dimensionsForRequest(testCase.Request),
+			Tags:               testCase.Tags,
+			Server: listServer{
+				Protocol:          svrInstance.protocol.String(),
+				HTTPVersion:       svrInstance.httpVersion.String(),
+				UseTLS:            svrInstance.useTLS,
+				UseTLSClientCerts: svrInstance.useTLSClientCerts,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		printer.Printf("%s", data)
+	}
+	return nil
+}
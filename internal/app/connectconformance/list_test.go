@@ -0,0 +1,87 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configFile, []byte(`features:
+  versions: [HTTP_VERSION_1]
+  protocols: [PROTOCOL_CONNECT]
+  codecs: [CODEC_PROTO]
+  compressions: [COMPRESSION_IDENTITY]
+  streamTypes: [STREAM_TYPE_UNARY]
+  supportsH2c: false
+  supportsTls: false
+`), 0600)
+	require.NoError(t, err)
+	testFile := filepath.Join(dir, "suite.yaml")
+	err = os.WriteFile(testFile, []byte(`name: Basic
+testCases:
+- request:
+    testName: unary/b
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+- request:
+    testName: unary/a
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+`), 0600)
+	require.NoError(t, err)
+
+	flags := &ListFlags{
+		Mode:       "client",
+		ConfigFile: configFile,
+		TestFiles:  []string{testFile},
+	}
+	printer := &internal.SimplePrinter{}
+	require.NoError(t, List(flags, printer))
+	assert.Equal(t, []string{
+		"Basic/HTTPVersion:1/Protocol:PROTOCOL_CONNECT/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary/a\n",
+		"Basic/HTTPVersion:1/Protocol:PROTOCOL_CONNECT/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary/b\n",
+	}, printer.Messages)
+
+	flags.SkipPatterns = []string{"**/unary/b"}
+	flags.JSON = true
+	printer = &internal.SimplePrinter{}
+	require.NoError(t, List(flags, printer))
+	assert.Equal(t, []string{
+		`{"name":"Basic/HTTPVersion:1/Protocol:PROTOCOL_CONNECT/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary/a",` +
+			`"protocol":"PROTOCOL_CONNECT","httpVersion":"HTTP_VERSION_1","codec":"CODEC_PROTO","compression":"COMPRESSION_IDENTITY",` +
+			`"streamType":"STREAM_TYPE_UNARY","useTls":false,"useTlsClientCerts":false,` +
+			`"server":{"protocol":"PROTOCOL_CONNECT","httpVersion":"HTTP_VERSION_1","useTls":false,"useTlsClientCerts":false}}` + "\n",
+	}, printer.Messages)
+
+	flags.SkipPatterns = []string{"**/unary/c"}
+	err = List(flags, &internal.SimplePrinter{})
+	require.ErrorContains(t, err, "unmatched and possibly invalid patterns")
+
+	flags.Mode = "foo"
+	err = List(flags, &internal.SimplePrinter{})
+	require.ErrorContains(t, err, "invalid mode")
+}
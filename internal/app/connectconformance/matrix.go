@@ -0,0 +1,416 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/bufbuild/protoyaml-go"
+	"gopkg.in/yaml.v3"
+)
+
+//nolint:gochecknoglobals
+var (
+	matrixPlaceholder = regexp.MustCompile(`\$\{([^}]*)\}`)
+	matrixAxisName    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// matrixAxis is one axis of a test case's matrix. See the docs for the
+// matrix field of connectrpc.conformance.v1.TestCase.
+type matrixAxis struct {
+	name   string
+	values []string
+}
+
+// expandSuiteMatrices expands test cases in the given test suite YAML that
+// define a matrix into one test case per combination of the matrix's values.
+//
+// The expansion is done on the YAML, before it is unmarshalled into a
+// TestSuite message, so that placeholders can be used in fields whose values
+// are not strings, such as enums. It returns the YAML data to unmarshal,
+// which is the given data if no test case defines a matrix. It also returns
+// the parsed and expanded YAML, which can be used to find the location of
+// fields in the original source, since expanded test cases retain the line
+// and column information of the template from which they came.
+func expandSuiteMatrices(testFilePath string, data []byte) ([]byte, *yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		// Let the caller report syntax errors when it unmarshals the data.
+		return data, &root, nil //nolint:nilerr
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return data, &root, nil
+	}
+	testCases := mappingValue(root.Content[0], "testCases", "test_cases")
+	if testCases == nil || testCases.Kind != yaml.SequenceNode {
+		return data, &root, nil
+	}
+	var expanded bool
+	var errs []error
+	newContent := make([]*yaml.Node, 0, len(testCases.Content))
+	for _, testCase := range testCases.Content {
+		matrixIndex := mappingIndex(testCase, "matrix")
+		if matrixIndex < 0 {
+			newContent = append(newContent, testCase)
+			continue
+		}
+		expanded = true
+		cases, err := expandMatrix(testCase, matrixIndex)
+		if err != nil {
+			errs = append(errs, prefixMatrixErrors(testFilePath, err)...)
+			continue
+		}
+		newContent = append(newContent, cases...)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	if !expanded {
+		return data, &root, nil
+	}
+	testCases.Content = newContent
+	expandedData, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to encode expanded test cases: %w", testFilePath, err)
+	}
+	return expandedData, &root, nil
+}
+
+// unmarshalTestSuite expands any matrices in the given test suite YAML (see
+// expandSuiteMatrices) and then unmarshals it. It also returns the parsed and
+// expanded YAML. Since errors from unmarshalling expanded YAML would refer to
+// locations in that YAML, they are changed to refer to the same locations in
+// the given data.
+func unmarshalTestSuite(testFilePath string, data []byte) (*conformancev1.TestSuite, *yaml.Node, error) {
+	expandedData, root, err := expandSuiteMatrices(testFilePath, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	suite := &conformancev1.TestSuite{}
+	opts := protoyaml.UnmarshalOptions{Path: testFilePath}
+	if err := opts.Unmarshal(expandedData, suite); err != nil {
+		if string(expandedData) != string(data) {
+			err = toSourceErrors(testFilePath, data, expandedData, root, err)
+		}
+		return nil, nil, internal.EnsureFileName(err, testFilePath)
+	}
+	return suite, root, nil
+}
+
+// toSourceErrors changes the locations in the given errors, which are from
+// unmarshalling the given expanded YAML, to refer to the given source data
+// instead. The given root is the expanded YAML as returned from
+// expandSuiteMatrices, whose nodes have locations in the source data.
+// Errors without a location are returned as is.
+func toSourceErrors(testFilePath string, data, expandedData []byte, root *yaml.Node, err error) error {
+	var expandedRoot yaml.Node
+	if yaml.Unmarshal(expandedData, &expandedRoot) != nil {
+		return err
+	}
+	sourcePositions := map[[2]int][2]int{}
+	mapSourcePositions(&expandedRoot, root, sourcePositions)
+	lines := strings.Split(string(data), "\n")
+	errs := splitJoinedErrors(err)
+	for i, err := range errs {
+		// The errors are formatted as "path:line:column message", followed
+		// by the line of YAML that has the error.
+		var line, column int
+		location, _, _ := strings.Cut(strings.TrimPrefix(err.Error(), testFilePath+":"), " ")
+		if _, scanErr := fmt.Sscanf(location, "%d:%d", &line, &column); scanErr != nil {
+			continue
+		}
+		cause := errors.Unwrap(err)
+		pos, ok := sourcePositions[[2]int{line, column}]
+		if cause == nil || !ok || pos[0] < 1 || pos[0] > len(lines) || pos[1] < 1 {
+			continue
+		}
+		line, column = pos[0], pos[1]
+		errs[i] = fmt.Errorf("%s:%d:%d %w\n%4d | %s\n%4d | %s^\n",
+			testFilePath, line, column, cause,
+			line, lines[line-1],
+			line, strings.Repeat(".", column-1))
+	}
+	return errors.Join(errs...)
+}
+
+// mapSourcePositions adds the location of every node in the given expanded
+// YAML to positions, mapped to the location of the corresponding node in
+// the given source YAML, which must have the same structure.
+func mapSourcePositions(expanded, source *yaml.Node, positions map[[2]int][2]int) {
+	positions[[2]int{expanded.Line, expanded.Column}] = [2]int{source.Line, source.Column}
+	if len(expanded.Content) != len(source.Content) {
+		return
+	}
+	for i := range expanded.Content {
+		mapSourcePositions(expanded.Content[i], source.Content[i], positions)
+	}
+}
+
+// expandMatrix expands the given test case, whose matrix is the mapping entry
+// at the given index, into one test case per combination of matrix values.
+func expandMatrix(testCase *yaml.Node, matrixIndex int) ([]*yaml.Node, error) {
+	axes, err := parseMatrix(testCase.Content[matrixIndex+1])
+	if err != nil {
+		return nil, err
+	}
+	// The template is the test case without the matrix.
+	template := *testCase
+	template.Content = make([]*yaml.Node, 0, len(testCase.Content)-2)
+	template.Content = append(template.Content, testCase.Content[:matrixIndex]...)
+	template.Content = append(template.Content, testCase.Content[matrixIndex+2:]...)
+
+	axesByName := make(map[string]struct{}, len(axes))
+	for _, axis := range axes {
+		axesByName[axis.name] = struct{}{}
+	}
+	var errs []error
+	checkPlaceholders(&template, axesByName, &errs)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	// Axes that the test name does not refer to are added as suffixes.
+	var nameSuffixAxes []int
+	testName := mappingValue(mappingValue(&template, "request"), "testName", "test_name")
+	for i, axis := range axes {
+		if testName == nil || !strings.Contains(testName.Value, "${"+axis.name+"}") {
+			nameSuffixAxes = append(nameSuffixAxes, i)
+		}
+	}
+
+	var results []*yaml.Node
+	indexes := make([]int, len(axes))
+	for {
+		values := make(map[string]string, len(axes))
+		for i, axis := range axes {
+			values[axis.name] = axis.values[indexes[i]]
+		}
+		result := substitutePlaceholders(&template, values)
+		if len(nameSuffixAxes) > 0 {
+			// Replace the test name node, which was not copied since it
+			// contains no placeholders for these axes.
+			resultName := mappingValue(mappingValue(result, "request"), "testName", "test_name")
+			if resultName != nil {
+				nameCopy := *resultName
+				for _, i := range nameSuffixAxes {
+					nameCopy.Value += "/" + axes[i].values[indexes[i]]
+				}
+				nameCopy.Tag = ""
+				replaceNode(result, resultName, &nameCopy)
+			}
+		}
+		results = append(results, result)
+		// Advance to the next combination, with the last axis varying fastest.
+		i := len(indexes) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(axes[i].values) {
+				break
+			}
+			indexes[i] = 0
+		}
+		if i < 0 {
+			return results, nil
+		}
+	}
+}
+
+// parseMatrix parses the given YAML node, which is the value of a test
+// case's matrix field, into a list of axes.
+func parseMatrix(node *yaml.Node) ([]matrixAxis, error) {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return nil, newMatrixError(node, "matrix must be a non-empty list of axes")
+	}
+	var errs []error
+	axes := make([]matrixAxis, 0, len(node.Content))
+	names := map[string]struct{}{}
+	for _, axisNode := range node.Content {
+		if axisNode.Kind != yaml.MappingNode {
+			errs = append(errs, newMatrixError(axisNode, "matrix axis must have a name and values"))
+			continue
+		}
+		var axis matrixAxis
+		for i := 0; i+1 < len(axisNode.Content); i += 2 {
+			key, value := axisNode.Content[i], axisNode.Content[i+1]
+			switch key.Value {
+			case "name":
+				axis.name = value.Value
+				if value.Kind != yaml.ScalarNode || !matrixAxisName.MatchString(value.Value) {
+					errs = append(errs, newMatrixError(value, "matrix axis name must contain only letters, digits, and underscores and must not start with a digit"))
+				} else if _, exists := names[axis.name]; exists {
+					errs = append(errs, newMatrixError(value, fmt.Sprintf("matrix has more than one axis named %q", axis.name)))
+				}
+				names[axis.name] = struct{}{}
+			case "values":
+				if value.Kind != yaml.SequenceNode || len(value.Content) == 0 {
+					errs = append(errs, newMatrixError(value, "matrix axis values must be a non-empty list"))
+					continue
+				}
+				seen := map[string]struct{}{}
+				for _, valueNode := range value.Content {
+					if valueNode.Kind != yaml.ScalarNode {
+						errs = append(errs, newMatrixError(valueNode, "matrix axis value must be a scalar"))
+						continue
+					}
+					if _, exists := seen[valueNode.Value]; exists {
+						errs = append(errs, newMatrixError(valueNode, fmt.Sprintf("matrix axis has duplicate value %q", valueNode.Value)))
+					}
+					seen[valueNode.Value] = struct{}{}
+					axis.values = append(axis.values, valueNode.Value)
+				}
+			default:
+				errs = append(errs, newMatrixError(key, fmt.Sprintf("unknown matrix axis field %q", key.Value)))
+			}
+		}
+		switch {
+		case axis.name == "":
+			errs = append(errs, newMatrixError(axisNode, "matrix axis has no name"))
+		case mappingValue(axisNode, "values") == nil:
+			errs = append(errs, newMatrixError(axisNode, fmt.Sprintf("matrix axis %q has no values", axis.name)))
+		}
+		axes = append(axes, axis)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return axes, nil
+}
+
+// checkPlaceholders adds an error to errs for every placeholder in the given
+// node, or its descendants, that does not refer to one of the given axes.
+func checkPlaceholders(node *yaml.Node, axes map[string]struct{}, errs *[]error) {
+	if node.Kind == yaml.ScalarNode {
+		for _, match := range matrixPlaceholder.FindAllStringSubmatch(node.Value, -1) {
+			if _, ok := axes[match[1]]; !ok {
+				*errs = append(*errs, newMatrixError(node, fmt.Sprintf("placeholder %s does not refer to a matrix axis", match[0])))
+			}
+		}
+		return
+	}
+	for _, child := range node.Content {
+		checkPlaceholders(child, axes, errs)
+	}
+}
+
+// substitutePlaceholders returns a copy of the given node in which all
+// placeholders have been replaced with the given values. Nodes that contain
+// no placeholders are shared with the given node, not copied.
+func substitutePlaceholders(node *yaml.Node, values map[string]string) *yaml.Node {
+	if node.Kind == yaml.ScalarNode {
+		if !matrixPlaceholder.MatchString(node.Value) {
+			return node
+		}
+		result := *node
+		result.Value = matrixPlaceholder.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			return values[placeholder[2:len(placeholder)-1]]
+		})
+		// Clear the tag so that it is re-resolved from the new value. This
+		// allows a placeholder to be used for a number or boolean.
+		result.Tag = ""
+		return &result
+	}
+	if len(node.Content) == 0 {
+		return node
+	}
+	result := *node
+	result.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		result.Content[i] = substitutePlaceholders(child, values)
+	}
+	return &result
+}
+
+// replaceNode replaces the given old node, which must be a descendant of the
+// given root, with the given new node. Since nodes may be shared between
+// expanded test cases, the path from root to the old node is copied first.
+func replaceNode(root, oldNode, newNode *yaml.Node) bool {
+	for i, child := range root.Content {
+		if child == oldNode {
+			root.Content = append([]*yaml.Node(nil), root.Content...)
+			root.Content[i] = newNode
+			return true
+		}
+		childCopy := *child
+		if len(child.Content) > 0 && replaceNode(&childCopy, oldNode, newNode) {
+			root.Content = append([]*yaml.Node(nil), root.Content...)
+			root.Content[i] = &childCopy
+			return true
+		}
+	}
+	return false
+}
+
+// mappingIndex returns the index in the given mapping node's content of the
+// key with one of the given names, or -1 if it has none of them.
+func mappingIndex(node *yaml.Node, keys ...string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		for _, key := range keys {
+			if node.Content[i].Value == key {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the value in the given mapping node for the key with
+// one of the given names, or nil if it has none of them.
+func mappingValue(node *yaml.Node, keys ...string) *yaml.Node {
+	index := mappingIndex(node, keys...)
+	if index < 0 {
+		return nil
+	}
+	return node.Content[index+1]
+}
+
+func newMatrixError(node *yaml.Node, msg string) error {
+	return &matrixError{line: node.Line, column: node.Column, msg: msg}
+}
+
+// matrixError is a problem with a test case matrix, at the given location.
+type matrixError struct {
+	line, column int
+	msg          string
+}
+
+func (e *matrixError) Error() string {
+	return fmt.Sprintf("%d:%d %s", e.line, e.column, e.msg)
+}
+
+// prefixMatrixErrors returns the errors in err, which may be the result of
+// errors.Join, with the given file name added to their messages.
+func prefixMatrixErrors(testFilePath string, err error) []error {
+	errs := splitJoinedErrors(err)
+	for i, err := range errs {
+		errs[i] = fmt.Errorf("%s:%w", testFilePath, err)
+	}
+	return errs
+}
+
+// splitJoinedErrors returns the errors in err if it is the result of
+// errors.Join. Otherwise, it returns a slice with just err.
+func splitJoinedErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		return append([]error(nil), joined.Unwrap()...)
+	}
+	return []error{err}
+}
@@ -0,0 +1,187 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSuiteMatrices(t *testing.T) {
+	t.Parallel()
+	suites, err := parseTestSuites(map[string][]byte{"matrix.yaml": []byte(`name: Matrix
+testCases:
+- request:
+    testName: plain
+    streamType: STREAM_TYPE_UNARY
+- matrix:
+  - name: code
+    values: [CODE_ABORTED, CODE_INTERNAL]
+  - name: delay
+    values: ["0", "100"]
+  request:
+    testName: errors/${code}
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+      responseDefinition:
+        responseDelayMs: ${delay}
+        error:
+          code: ${code}
+          message: failed with ${code}
+`)})
+	require.NoError(t, err)
+	suite := suites["matrix.yaml"]
+	require.NotNil(t, suite)
+
+	type expandedCase struct {
+		name    string
+		code    conformancev1.Code
+		message string
+		delay   uint32
+	}
+	var cases []expandedCase
+	for _, testCase := range suite.TestCases {
+		assert.Empty(t, testCase.Matrix)
+		result := expandedCase{name: testCase.Request.TestName}
+		if len(testCase.Request.RequestMessages) > 0 {
+			msg, err := testCase.Request.RequestMessages[0].UnmarshalNew()
+			require.NoError(t, err)
+			def := msg.(*conformancev1.UnaryRequest).GetResponseDefinition() //nolint:forcetypeassert
+			result.code = def.GetError().GetCode()
+			result.message = def.GetError().GetMessage()
+			result.delay = def.GetResponseDelayMs()
+		}
+		cases = append(cases, result)
+	}
+	assert.Equal(t, []expandedCase{
+		{name: "plain"},
+		{name: "errors/CODE_ABORTED/0", code: conformancev1.Code_CODE_ABORTED, message: "failed with CODE_ABORTED"},
+		{name: "errors/CODE_ABORTED/100", code: conformancev1.Code_CODE_ABORTED, message: "failed with CODE_ABORTED", delay: 100},
+		{name: "errors/CODE_INTERNAL/0", code: conformancev1.Code_CODE_INTERNAL, message: "failed with CODE_INTERNAL"},
+		{name: "errors/CODE_INTERNAL/100", code: conformancev1.Code_CODE_INTERNAL, message: "failed with CODE_INTERNAL", delay: 100},
+	}, cases)
+}
+
+func TestExpandSuiteMatrices_Errors(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		yaml        string
+		expectedErr string
+	}{
+		{
+			name: "empty matrix",
+			yaml: `testCases:
+- matrix: []
+  request:
+    testName: foo
+`,
+			expectedErr: "suite.yaml:2:11 matrix must be a non-empty list of axes",
+		},
+		{
+			name: "invalid axis name",
+			yaml: `testCases:
+- matrix:
+  - name: 1abc
+    values: [a]
+  request:
+    testName: foo
+`,
+			expectedErr: "suite.yaml:3:11 matrix axis name must contain only letters, digits, and underscores and must not start with a digit",
+		},
+		{
+			name: "duplicate axis name",
+			yaml: `testCases:
+- matrix:
+  - name: abc
+    values: [a]
+  - name: abc
+    values: [b]
+  request:
+    testName: foo
+`,
+			expectedErr: `suite.yaml:5:11 matrix has more than one axis named "abc"`,
+		},
+		{
+			name: "duplicate value",
+			yaml: `testCases:
+- matrix:
+  - name: abc
+    values: [a, b, a]
+  request:
+    testName: foo
+`,
+			expectedErr: `suite.yaml:4:20 matrix axis has duplicate value "a"`,
+		},
+		{
+			name: "no values",
+			yaml: `testCases:
+- matrix:
+  - name: abc
+  request:
+    testName: foo
+`,
+			expectedErr: `suite.yaml:3:5 matrix axis "abc" has no values`,
+		},
+		{
+			name: "unknown placeholder",
+			yaml: `testCases:
+- matrix:
+  - name: abc
+    values: [a]
+  request:
+    testName: foo/${abc}
+    service: ${xyz}
+`,
+			expectedErr: "suite.yaml:7:14 placeholder ${xyz} does not refer to a matrix axis",
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := expandSuiteMatrices("suite.yaml", []byte(testCase.yaml))
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestUnmarshalTestSuite_ErrorLocations(t *testing.T) {
+	t.Parallel()
+	_, _, err := unmarshalTestSuite("suite.yaml", []byte(`name: Matrix
+# A comment, so that the expanded YAML has different line numbers.
+testCases:
+- matrix:
+  - name: code
+    values: [CODE_ABORTED, CODE_BOGUS]
+  request:
+    testName: errors/${code}
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+      responseDefinition:
+        error:
+          code: ${code}
+`))
+	require.Error(t, err)
+	// The location and snippet are from the source, not the expanded YAML.
+	assert.Regexp(t, `^suite\.yaml:14:17 unknown enum value "CODE_BOGUS".*\n`+
+		`  14 \|           code: \$\{code\}\n`+
+		`  14 \| \.{16}\^\n$`, err.Error())
+}
@@ -23,6 +23,9 @@ import (
 	"os/exec"
 	"syscall"
 	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 )
 
 const (
@@ -55,14 +58,36 @@ type processInfo struct {
 	start           processStarter
 	isReferenceImpl bool
 	isGrpcImpl      bool
+	// The format of messages written to the process's stdin
+	// and read from its stdout.
+	stdioFormat conformancev1.StdioFormat
+}
+
+// newStdioCodec returns the codec used to exchange messages with a client
+// or server process over its stdin and stdout, in the given format.
+func newStdioCodec(format conformancev1.StdioFormat) internal.Codec {
+	if format == conformancev1.StdioFormat_STDIO_FORMAT_JSON {
+		return internal.NewJSONLinesCodec()
+	}
+	return internal.NewCodec(false)
 }
 
 // runCommand returns a process starter that invokes the given command-line in
 // a separate OS process.
 func runCommand(command []string) processStarter {
+	return runCommandWithEnv(command, nil)
+}
+
+// runCommandWithEnv is like runCommand, except that the given environment
+// variables, each in "key=value" form, are added to the environment of the
+// process.
+func runCommandWithEnv(command []string, env []string) processStarter {
 	return makeProcess(func(ctx context.Context, stdin io.ReadCloser, stdout, stderr io.WriteCloser) (processController, error) {
 		ctx, cancel := context.WithCancel(ctx)
 		cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec
+		if len(env) > 0 {
+			cmd.Env = append(os.Environ(), env...)
+		}
 		cmd.Stdin = stdin
 		cmd.Stdout = stdout
 		cmd.Stderr = stderr
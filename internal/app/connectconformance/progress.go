@@ -0,0 +1,249 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// ProgressMode indicates how the progress of a test run is displayed.
+type ProgressMode int
+
+const (
+	// ProgressOff disables the progress display.
+	ProgressOff = ProgressMode(iota)
+	// ProgressLog periodically logs a line that describes the progress.
+	ProgressLog
+	// ProgressTerminal shows a status line that is updated in place. This
+	// should only be used when the progress writer is a terminal.
+	ProgressTerminal
+)
+
+const (
+	progressLogInterval      = 30 * time.Second
+	progressTerminalInterval = 250 * time.Millisecond
+)
+
+// progress tracks the progress of a test run and periodically displays
+// it. A nil *progress is valid and displays nothing.
+type progress struct {
+	mode     ProgressMode
+	writer   io.Writer // for ProgressTerminal
+	printer  internal.Printer
+	interval time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// Set when the status line is currently shown in the terminal,
+	// so that it can be cleared before printing other messages.
+	shown         bool
+	start         time.Time
+	total         int
+	failed        map[string]bool
+	numFailed     int
+	activeServers int
+	done          chan struct{}
+	stopped       chan struct{}
+}
+
+// newProgress returns a progress display for the given mode. It returns
+// nil if mode is ProgressOff. Messages are printed to printer in
+// ProgressLog mode; the status line is written to writer in
+// ProgressTerminal mode.
+func newProgress(mode ProgressMode, writer io.Writer, printer internal.Printer) *progress {
+	var interval time.Duration
+	switch mode {
+	case ProgressLog:
+		interval = progressLogInterval
+	case ProgressTerminal:
+		if writer == nil {
+			return nil
+		}
+		interval = progressTerminalInterval
+	default:
+		return nil
+	}
+	return &progress{
+		mode:     mode,
+		writer:   writer,
+		printer:  printer,
+		interval: interval,
+		now:      time.Now,
+		failed:   map[string]bool{},
+	}
+}
+
+// run starts periodically displaying the progress of the given total
+// number of test cases. It must be followed by a call to stop.
+func (p *progress) run(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.start = p.now()
+	p.total = total
+	p.done = make(chan struct{})
+	p.stopped = make(chan struct{})
+	p.mu.Unlock()
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.display()
+			}
+		}
+	}()
+}
+
+// stop stops displaying progress and clears the status line, if shown.
+func (p *progress) stop() {
+	if p == nil || p.done == nil {
+		return
+	}
+	close(p.done)
+	<-p.stopped
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clearLocked()
+}
+
+// setStatus records the status of the named test case. It may be called
+// more than once for the same test case, such as when a flaky test case is
+// retried, in which case the latest status replaces the earlier one.
+func (p *progress) setStatus(testCase string, status outcomeStatus) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	failed := status.isFailure()
+	if prevFailed, ok := p.failed[testCase]; ok && prevFailed {
+		p.numFailed--
+	}
+	p.failed[testCase] = failed
+	if failed {
+		p.numFailed++
+	}
+}
+
+// skip removes the given number of test cases from the total, for
+// test cases that will not be run.
+func (p *progress) skip(numTestCases int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total -= numTestCases
+}
+
+// serverStarted records that a server instance has started.
+func (p *progress) serverStarted() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.activeServers++
+}
+
+// serverStopped records that a server instance has stopped.
+func (p *progress) serverStopped() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.activeServers--
+}
+
+func (p *progress) display() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := p.statusLocked()
+	if p.mode == ProgressLog {
+		p.printer.Printf("Progress: %s", status)
+		return
+	}
+	_, _ = fmt.Fprintf(p.writer, "\r\x1b[K%s", status)
+	p.shown = true
+}
+
+func (p *progress) clearLocked() {
+	if !p.shown {
+		return
+	}
+	_, _ = io.WriteString(p.writer, "\r\x1b[K")
+	p.shown = false
+}
+
+// statusLocked describes the current progress in a single line.
+func (p *progress) statusLocked() string {
+	completed := len(p.failed)
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "%d/%d test case(s)", completed, p.total)
+	if p.total > 0 {
+		_, _ = fmt.Fprintf(&buf, " (%d%%)", completed*100/p.total)
+	}
+	_, _ = fmt.Fprintf(&buf, ", %d passed, %d failed, %d server(s) active",
+		completed-p.numFailed, p.numFailed, p.activeServers)
+	elapsed := p.now().Sub(p.start)
+	_, _ = fmt.Fprintf(&buf, ", %s elapsed", elapsed.Round(time.Second))
+	if completed > 0 && completed < p.total {
+		remaining := time.Duration(float64(elapsed) * float64(p.total-completed) / float64(completed))
+		_, _ = fmt.Fprintf(&buf, ", about %s remaining", remaining.Round(time.Second))
+	}
+	return buf.String()
+}
+
+// wrap returns a printer that, in ProgressTerminal mode, clears the status
+// line before printing, so that messages are not interleaved with it. The
+// status line is shown again on the next update.
+func (p *progress) wrap(printer internal.Printer) internal.Printer {
+	if p == nil || p.mode != ProgressTerminal {
+		return printer
+	}
+	return &progressPrinter{progress: p, printer: printer}
+}
+
+type progressPrinter struct {
+	progress *progress
+	printer  internal.Printer
+}
+
+func (p *progressPrinter) Printf(msg string, args ...any) {
+	p.progress.mu.Lock()
+	defer p.progress.mu.Unlock()
+	p.progress.clearLocked()
+	p.printer.Printf(msg, args...)
+}
+
+func (p *progressPrinter) PrefixPrintf(prefix, msg string, args ...any) {
+	p.progress.mu.Lock()
+	defer p.progress.mu.Unlock()
+	p.progress.clearLocked()
+	p.printer.PrefixPrintf(prefix, msg, args...)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress_Log(t *testing.T) {
+	t.Parallel()
+	logger := &internal.SimplePrinter{}
+	prog := newProgress(ProgressLog, nil, logger)
+	require.NotNil(t, prog)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	prog.now = func() time.Time { return now }
+	prog.interval = time.Hour // only display when explicitly asked to
+	prog.run(10)
+	defer prog.stop()
+
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.progress = prog
+	prog.serverStarted()
+	prog.serverStarted()
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("ruh roh"))
+	results.setOutcome("known-to-fail/1", false, errors.New("ruh roh"))
+	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
+	// A retry replaces the earlier outcome.
+	results.setOutcome("known-to-flake/1", false, nil)
+	now = start.Add(time.Minute)
+	prog.display()
+
+	prog.serverStopped()
+	results.skip([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/baz/1"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/baz/2"}},
+	})
+	now = start.Add(2 * time.Minute)
+	prog.display()
+
+	assert.Equal(t, []string{
+		"Progress: 4/10 test case(s) (40%), 3 passed, 1 failed, 2 server(s) active, 1m0s elapsed, about 1m30s remaining\n",
+		"Progress: 4/8 test case(s) (50%), 3 passed, 1 failed, 1 server(s) active, 2m0s elapsed, about 2m0s remaining\n",
+	}, logger.Messages)
+}
+
+func TestProgress_Terminal(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	logger := &internal.SimplePrinter{}
+	prog := newProgress(ProgressTerminal, &buf, logger)
+	require.NotNil(t, prog)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prog.now = func() time.Time { return start }
+	prog.interval = time.Hour // only display when explicitly asked to
+	prog.run(2)
+
+	printer := prog.wrap(logger)
+	prog.display()
+	printer.Printf("hello")
+	printer.Printf("world")
+	prog.setStatus("foo/bar/1", statusPassed)
+	prog.setStatus("foo/bar/2", statusPassed)
+	prog.display()
+	prog.stop()
+
+	assert.Equal(t, "\r\x1b[K0/2 test case(s) (0%), 0 passed, 0 failed, 0 server(s) active, 0s elapsed"+
+		"\r\x1b[K"+
+		"\r\x1b[K2/2 test case(s) (100%), 2 passed, 0 failed, 0 server(s) active, 0s elapsed"+
+		"\r\x1b[K", buf.String())
+	assert.Equal(t, []string{"hello\n", "world\n"}, logger.Messages)
+}
+
+func TestProgress_Off(t *testing.T) {
+	t.Parallel()
+	prog := newProgress(ProgressOff, &bytes.Buffer{}, &internal.SimplePrinter{})
+	assert.Nil(t, prog)
+	// All methods are no-ops on a nil progress.
+	prog.run(10)
+	prog.setStatus("foo/bar/1", statusFailed)
+	prog.serverStarted()
+	prog.stop()
+	logger := &internal.SimplePrinter{}
+	assert.Same(t, logger, prog.wrap(logger))
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -48,14 +49,41 @@ const timeoutCheckGracePeriodMillis = 250
 type testResults struct {
 	knownFailing *testTrie
 	knownFlaky   *testTrie
-	tracer       *tracer.Tracer
+	// Test cases with any of these tags are also known to fail or be flaky.
+	knownFailingTags []string
+	knownFlakyTags   []string
+	tracer           *tracer.Tracer
+	// The number of times a failing, known-flaky test case is retried.
+	flakyRetries uint
+	// If true, failures of SHOULD-level test cases and feedback are not
+	// treated as warnings but instead as failures.
+	strict bool
+	// If non-zero, stopDispatch is called once this many test cases fail.
+	maxFailures  uint
+	stopDispatch context.CancelFunc
+	// If non-nil, the progress display is updated as outcomes are recorded.
+	progress *progress
+	// If true, only some test cases were selected to run, because they
+	// were filtered or sharded.
+	filtered bool
 
 	traceWaitGroup sync.WaitGroup
 
 	mu             sync.Mutex
 	outcomes       map[string]testOutcome
 	traces         map[string]*tracer.Trace
-	serverSideband map[string]string
+	serverSideband map[string]*internal.Feedback
+	timings        map[string]testTiming
+	serverTimings  []serverTiming
+	requests       map[string]*conformancev1.ClientCompatRequest
+	tags           map[string][]string
+	severities     map[string]conformancev1.Severity
+	attempts       map[string]int
+	skipped        map[string]struct{}
+	// The number of test cases that have failed so far, for
+	// comparing to maxFailures.
+	failures int
+	stopped  bool
 }
 
 func newResults(knownFailing, knownFlaky *testTrie, tracer *tracer.Tracer) *testResults {
@@ -64,7 +92,32 @@ func newResults(knownFailing, knownFlaky *testTrie, tracer *tracer.Tracer) *test
 		knownFlaky:     knownFlaky,
 		tracer:         tracer,
 		outcomes:       map[string]testOutcome{},
-		serverSideband: map[string]string{},
+		serverSideband: map[string]*internal.Feedback{},
+		timings:        map[string]testTiming{},
+		requests:       map[string]*conformancev1.ClientCompatRequest{},
+		tags:           map[string][]string{},
+		severities:     map[string]conformancev1.Severity{},
+		attempts:       map[string]int{},
+		skipped:        map[string]struct{}{},
+	}
+}
+
+// addTestCases records the requests, tags, and severities for the given test
+// cases, so that the configuration of each test case can be included in reports.
+// This must be called before any outcomes are recorded for the test cases, so
+// that test cases that are known to fail by tag are correctly identified and
+// so that failures of SHOULD-level test cases are reported as warnings.
+func (r *testResults) addTestCases(testCases []*conformancev1.TestCase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, testCase := range testCases {
+		r.requests[testCase.Request.TestName] = testCase.Request
+		if len(testCase.Tags) > 0 {
+			r.tags[testCase.Request.TestName] = testCase.Tags
+		}
+		if testCase.Severity != conformancev1.Severity_SEVERITY_UNSPECIFIED {
+			r.severities[testCase.Request.TestName] = testCase.Severity
+		}
 	}
 }
 
@@ -79,15 +132,67 @@ func (r *testResults) setOutcome(testCase string, setupError bool, err error) {
 }
 
 func (r *testResults) setOutcomeLocked(testCase string, setupError bool, err error) {
-	r.outcomes[testCase] = testOutcome{
+	r.setOutcomeWithSeverityLocked(testCase, setupError, err, r.severities[testCase])
+}
+
+// setOutcomeWithSeverityLocked is like setOutcomeLocked, except that a failure
+// has the given severity instead of that of the test case.
+func (r *testResults) setOutcomeWithSeverityLocked(testCase string, setupError bool, err error, severity conformancev1.Severity) {
+	outcome := testOutcome{
 		actualFailure: err,
 		setupError:    setupError,
-		knownFailing:  r.knownFailing.match(strings.Split(testCase, "/")),
-		knownFlaky:    r.knownFlaky.match(strings.Split(testCase, "/")),
+		knownFailing:  r.knownFailing.match(strings.Split(testCase, "/")) || hasAnyTag(r.tags[testCase], r.knownFailingTags),
+		knownFlaky:    r.knownFlaky.match(strings.Split(testCase, "/")) || hasAnyTag(r.tags[testCase], r.knownFlakyTags),
+		warning: err != nil && !setupError && !r.strict &&
+			severity == conformancev1.Severity_SEVERITY_SHOULD,
+	}
+	if feedback, ok := r.serverSideband[testCase]; ok {
+		// Feedback that arrived before the outcome is merged into it now.
+		delete(r.serverSideband, testCase)
+		outcome = r.withSidebandLocked(outcome, feedback)
 	}
+	r.updateOutcomeLocked(testCase, outcome, statusPassed)
 	r.fetchTrace(testCase)
 }
 
+// updateOutcomeLocked stores the given outcome for the named test case and
+// updates the progress display and the count of failures. The given status
+// is that of the test case's previous outcome, so that a failure is only
+// counted once.
+func (r *testResults) updateOutcomeLocked(testCase string, outcome testOutcome, prevStatus outcomeStatus) {
+	r.outcomes[testCase] = outcome
+	status := outcome.status()
+	r.progress.setStatus(testCase, status)
+	if status.isFailure() && !prevStatus.isFailure() {
+		r.failures++
+		if r.maxFailures > 0 && r.failures >= int(r.maxFailures) && !r.stopped {
+			r.stopped = true
+			if r.stopDispatch != nil {
+				r.stopDispatch()
+			}
+		}
+	}
+}
+
+// dispatchStopped returns true if the maximum number of failures has been
+// reached, in which case no more test cases should be sent to the client.
+func (r *testResults) dispatchStopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stopped
+}
+
+// skip records that the given test cases were not run because the maximum
+// number of failures was reached.
+func (r *testResults) skip(testCases []*conformancev1.TestCase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, testCase := range testCases {
+		r.skipped[testCase.Request.TestName] = struct{}{}
+	}
+	r.progress.skip(len(testCases))
+}
+
 //nolint:contextcheck,nolintlint // intentionally using context.Background; nolintlint incorrectly complains about this
 func (r *testResults) fetchTrace(testCase string) {
 	if r.tracer == nil {
@@ -147,6 +252,9 @@ func (r *testResults) failRemaining(testCases []*conformancev1.TestCase, err err
 		if _, outcomeExists := r.outcomes[name]; outcomeExists {
 			continue
 		}
+		if _, skipped := r.skipped[name]; skipped {
+			continue
+		}
 		r.setOutcomeLocked(testCase.Request.TestName, true, err)
 	}
 }
@@ -165,11 +273,16 @@ func (r *testResults) assert(
 	actual *conformancev1.ClientResponseResult,
 ) {
 	expected := definition.ExpectedResponse
+	matchers := definition.ResponseMatchers
 	var errs multiErrors
 
-	errs = append(errs, checkError(expected.Error, actual.Error)...)
+	errs = append(errs, checkError(expected.Error, actual.Error, matchers)...)
 	errs = append(errs, checkPayloads(expected.Payloads, actual.Payloads)...)
 
+	// Headers and trailers that only need to be present are not compared by value.
+	expectedHeaders := withoutHeaders(expected.ResponseHeaders, matchers.GetResponseHeadersPresent())
+	expectedTrailers := withoutHeaders(expected.ResponseTrailers, matchers.GetResponseTrailersPresent())
+
 	if len(expected.Payloads) == 0 &&
 		expected.Error != nil &&
 		(definition.Request.StreamType == conformancev1.StreamType_STREAM_TYPE_UNARY ||
@@ -182,21 +295,26 @@ func (r *testResults) assert(
 		// sending back a ClientResponseResult message.
 
 		// So first we see if normal attribute succeeds
-		metadataErrs := checkHeaders("response headers", expected.ResponseHeaders, actual.ResponseHeaders)
-		metadataErrs = append(metadataErrs, checkHeaders("response trailers", expected.ResponseTrailers, actual.ResponseTrailers)...)
+		metadataErrs := checkHeaders("response headers", expectedHeaders, actual.ResponseHeaders)
+		metadataErrs = append(metadataErrs, checkHeaders("response trailers", expectedTrailers, actual.ResponseTrailers)...)
 		if len(metadataErrs) > 0 {
 			// That did not work. So we test to see if client attributed them all as trailers.
-			merged := mergeHeaders(expected.ResponseHeaders, expected.ResponseTrailers)
+			merged := mergeHeaders(expectedHeaders, expectedTrailers)
 			if allTrailersErrs := checkHeaders("response metadata", merged, actual.ResponseTrailers); len(allTrailersErrs) != 0 {
 				// That check failed also. So the received headers/trailers are incorrect.
 				// Report the original errors computed above.
 				errs = append(errs, metadataErrs...)
 			}
 		}
+		// Likewise, headers that must be present or absent may have been recorded as trailers.
+		allMetadata := append(append([]*conformancev1.Header(nil), actual.ResponseHeaders...), actual.ResponseTrailers...)
+		errs = append(errs, checkHeadersPresence("response headers", matchers.GetResponseHeadersPresent(), matchers.GetResponseHeadersAbsent(), allMetadata)...)
 	} else {
-		errs = append(errs, checkHeaders("response headers", expected.ResponseHeaders, actual.ResponseHeaders)...)
-		errs = append(errs, checkHeaders("response trailers", expected.ResponseTrailers, actual.ResponseTrailers)...)
+		errs = append(errs, checkHeaders("response headers", expectedHeaders, actual.ResponseHeaders)...)
+		errs = append(errs, checkHeaders("response trailers", expectedTrailers, actual.ResponseTrailers)...)
+		errs = append(errs, checkHeadersPresence("response headers", matchers.GetResponseHeadersPresent(), matchers.GetResponseHeadersAbsent(), actual.ResponseHeaders)...)
 	}
+	errs = append(errs, checkHeadersPresence("response trailers", matchers.GetResponseTrailersPresent(), matchers.GetResponseTrailersAbsent(), actual.ResponseTrailers)...)
 
 	if expected.HttpStatusCode != nil &&
 		actual.HttpStatusCode != nil &&
@@ -205,35 +323,116 @@ func (r *testResults) assert(
 			expected.GetHttpStatusCode(), actual.GetHttpStatusCode()))
 	}
 
-	r.setOutcome(testCase, false, errs.Result())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	severity := r.severities[testCase]
+	if messageSeverity := matchers.GetErrorMessageSeverity(); messageSeverity != conformancev1.Severity_SEVERITY_UNSPECIFIED &&
+		onlyErrorMessageMismatches(errs) {
+		severity = messageSeverity
+	}
+	r.setOutcomeWithSeverityLocked(testCase, false, errs.Result(), severity)
 }
 
-// recordSideband accepts an error message for a test that was sent
-// out-of-band by a reference server or included as feedback in the
-// response from a reference client.
-func (r *testResults) recordSideband(testCase string, errMsg string) {
+// retryFlaky returns the given test cases that should be run again, as
+// determined by retryIfFlakyLocked. This should only be called once all feedback
+// for the test cases' current attempt has been recorded.
+func (r *testResults) retryFlaky(testCases []*conformancev1.TestCase) []*conformancev1.TestCase {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.serverSideband[testCase] = errMsg
+	if r.stopped {
+		return nil
+	}
+	var retries []*conformancev1.TestCase
+	for _, testCase := range testCases {
+		if r.retryIfFlakyLocked(testCase.Request.TestName) {
+			retries = append(retries, testCase)
+		}
+	}
+	return retries
+}
+
+// retryIfFlakyLocked examines the outcome for the named test case and
+// returns true if it should be run again. That is the case when the test
+// case failed, is known to be flaky, and has not yet been retried the
+// maximum number of times. When it returns true, the outcome is discarded,
+// so the next attempt can record a new one.
+func (r *testResults) retryIfFlakyLocked(testCase string) bool {
+	if r.flakyRetries == 0 {
+		return false
+	}
+	outcome, ok := r.outcomes[testCase]
+	if !ok || outcome.setupError || !outcome.knownFlaky {
+		return false
+	}
+	if outcome.actualFailure == nil {
+		return false
+	}
+	attempts := r.attemptLocked(testCase)
+	if attempts > int(r.flakyRetries) {
+		return false
+	}
+	r.attempts[testCase] = attempts + 1
+	delete(r.outcomes, testCase)
+	delete(r.serverSideband, testCase)
+	return true
 }
 
-// processSidebandInfoLocked merges the data recorded during calls
-// to recordSideband into the outcomes. This is done when a report
-// is created.
+// attemptLocked returns the current attempt number for the named test
+// case, starting at one.
+func (r *testResults) attemptLocked(testCase string) int {
+	if attempts := r.attempts[testCase]; attempts > 0 {
+		return attempts
+	}
+	return 1
+}
+
+// recordSidebandForAttempt accepts feedback for a test that was sent
+// out-of-band by a reference server or included in the response from a
+// reference client. If the test case already has an outcome, the feedback
+// is merged into it right away. Otherwise, it is merged when the outcome
+// is set. The feedback is ignored if it is for an earlier attempt of a
+// retried test case.
+func (r *testResults) recordSidebandForAttempt(attempt int, feedback *internal.Feedback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if attempt != r.attemptLocked(feedback.TestName) {
+		return
+	}
+	r.recordSidebandLocked(feedback)
+}
+
+func (r *testResults) recordSidebandLocked(feedback *internal.Feedback) {
+	outcome, ok := r.outcomes[feedback.TestName]
+	if !ok {
+		r.serverSideband[feedback.TestName] = feedback
+		return
+	}
+	r.updateOutcomeLocked(feedback.TestName, r.withSidebandLocked(outcome, feedback), outcome.status())
+}
+
+// withSidebandLocked returns the given outcome, updated to include the
+// given feedback from a reference implementation.
+func (r *testResults) withSidebandLocked(outcome testOutcome, feedback *internal.Feedback) testOutcome {
+	// Feedback about recommended behavior is only a warning, as long as
+	// there are no other failures that are more severe.
+	outcome.warning = !r.strict && feedback.Severity == conformancev1.Severity_SEVERITY_SHOULD &&
+		(outcome.actualFailure == nil || outcome.warning)
+	if outcome.actualFailure == nil {
+		outcome.actualFailure = errors.New(feedback.Message)
+	} else {
+		outcome.actualFailure = fmt.Errorf("%s; %w", feedback.Message, outcome.actualFailure)
+	}
+	outcome.sideband = feedback.Message
+	return outcome
+}
+
+// processSidebandInfoLocked records outcomes for test cases that have
+// feedback from a reference implementation but no other outcome, such
+// as when the client under test never issued the RPC. This is done when
+// a report is created.
 func (r *testResults) processSidebandInfoLocked() {
-	for name, msg := range r.serverSideband {
-		outcome, ok := r.outcomes[name]
-		if ok {
-			// Update outcome to include reference server's feedback
-			if outcome.actualFailure == nil {
-				outcome.actualFailure = errors.New(msg)
-			} else {
-				outcome.actualFailure = fmt.Errorf("%s; %w", msg, outcome.actualFailure)
-			}
-			r.outcomes[name] = outcome
-		} else {
-			r.setOutcomeLocked(name, false, errors.New(msg))
-		}
+	for name := range r.serverSideband {
+		r.setOutcomeLocked(name, false, nil)
 	}
 }
 
@@ -241,25 +440,20 @@ func (r *testResults) report(printer internal.Printer) bool {
 	r.traceWaitGroup.Wait() // make sure all traces have been received
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if len(r.serverSideband) > 0 {
-		r.processSidebandInfoLocked()
-		r.serverSideband = map[string]string{}
-	}
-	testCaseNames := make([]string, 0, len(r.outcomes))
-	for testCaseName := range r.outcomes {
-		testCaseNames = append(testCaseNames, testCaseName)
-	}
+	r.processSidebandInfoLocked()
 	var succeeded, failed, expectedFailures int
-	sort.Strings(testCaseNames)
-	for _, name := range testCaseNames {
+	var warnings []string
+	failuresByTag := map[string]int{}
+	for _, name := range r.sortedNamesLocked() {
 		outcome := r.outcomes[name]
-		var expectError bool
-		if !outcome.setupError {
-			expectError = outcome.knownFailing ||
-				(outcome.knownFlaky && outcome.actualFailure != nil)
+		status := outcome.status()
+		if status.isFailure() {
+			for _, tag := range r.tags[name] {
+				failuresByTag[tag]++
+			}
 		}
-		switch {
-		case !expectError && outcome.actualFailure != nil:
+		switch status {
+		case statusFailed:
 			printer.Printf("FAILED: %s:\n%s", name, indent(outcome.actualFailure.Error()))
 			trace := r.traces[name]
 			if trace != nil {
@@ -268,17 +462,30 @@ func (r *testResults) report(printer internal.Printer) bool {
 				printer.Printf("--------------------")
 			}
 			failed++
-		case expectError && outcome.actualFailure == nil:
+		case statusUnexpectedPass:
 			printer.Printf("FAILED: %s was expected to fail but did not", name)
 			failed++
-		case expectError && outcome.actualFailure != nil:
-			printer.Printf("INFO: %s failed (as expected):\n%s", name, indent(outcome.actualFailure.Error()))
+		case statusExpectedFailure:
+			printer.Printf("INFO: %s failed (as expected)%s:\n%s", name, r.attemptsSuffixLocked(name), indent(outcome.actualFailure.Error()))
 			expectedFailures++
-		default:
+		case statusPassed:
+			if attempts := r.attempts[name]; attempts > 1 {
+				printer.Printf("INFO: %s passed after %d attempts", name, attempts)
+			}
 			succeeded++
+		case statusWarning:
+			warnings = append(warnings, name)
+		}
+	}
+	if len(warnings) > 0 {
+		// Warnings are reported separately, after all failures.
+		printer.Printf("\nWarnings (recommended behavior that is not required):")
+		for _, name := range warnings {
+			outcome := r.outcomes[name]
+			printer.Printf("WARNING: %s:\n%s", name, indent(outcome.actualFailure.Error()))
 		}
 	}
-	if failed+expectedFailures > 0 {
+	if failed+expectedFailures+len(warnings) > 0 {
 		// Add a blank line to separate summary from messages above
 		printer.Printf("\n")
 	}
@@ -286,9 +493,57 @@ func (r *testResults) report(printer internal.Printer) bool {
 	if expectedFailures > 0 {
 		printer.Printf("(Another %d failed as expected due to being known failures/flakes.)", expectedFailures)
 	}
+	if len(warnings) > 0 {
+		printer.Printf("(Another %d had warnings; use --strict to treat them as failures.)", len(warnings))
+	}
+	if len(r.skipped) > 0 {
+		printer.Printf("(Another %d were skipped after reaching the maximum of %d failure(s).)", len(r.skipped), r.maxFailures)
+	}
+	printFailuresByTag(printer, failuresByTag)
+	r.printSlowestLocked(printer, numSlowestToReport)
 	return failed == 0
 }
 
+// printFailuresByTag prints the number of failed test cases with each tag,
+// so that failures can be bucketed by area. Tags are printed in order of
+// decreasing failure count.
+func printFailuresByTag(printer internal.Printer, failuresByTag map[string]int) {
+	if len(failuresByTag) == 0 {
+		return
+	}
+	tags := make([]string, 0, len(failuresByTag))
+	for tag := range failuresByTag {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if failuresByTag[tags[i]] != failuresByTag[tags[j]] {
+			return failuresByTag[tags[i]] > failuresByTag[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	printer.Printf("\nFailures by tag:")
+	for _, tag := range tags {
+		printer.Printf("  %s: %d", tag, failuresByTag[tag])
+	}
+}
+
+func (r *testResults) attemptsSuffixLocked(testCase string) string {
+	attempts := r.attempts[testCase]
+	if attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" after %d attempts", attempts)
+}
+
+func (r *testResults) sortedNamesLocked() []string {
+	testCaseNames := make([]string, 0, len(r.outcomes))
+	for testCaseName := range r.outcomes {
+		testCaseNames = append(testCaseNames, testCaseName)
+	}
+	sort.Strings(testCaseNames)
+	return testCaseNames
+}
+
 type testOutcome struct {
 	// nil if the test case executed successfully, otherwise an error that
 	// represents why the test case failed, such as an error returned by the
@@ -302,6 +557,73 @@ type testOutcome struct {
 	knownFailing bool
 	// true if this test case is known to be flaky
 	knownFlaky bool
+	// feedback from the reference implementation, if any
+	sideband string
+	// if actualFailure != nil and warning is true, the failure only
+	// concerns recommended (SHOULD-level) behavior, so it is reported
+	// as a warning instead of a failure
+	warning bool
+}
+
+// outcomeStatus classifies a test outcome, accounting for whether
+// the test case was expected to fail.
+type outcomeStatus int
+
+const (
+	// The test case passed, as expected.
+	statusPassed = outcomeStatus(iota)
+	// The test case failed but was not expected to.
+	statusFailed
+	// The test case was expected to fail but passed.
+	statusUnexpectedPass
+	// The test case failed, but it is known to fail or known to be flaky.
+	statusExpectedFailure
+	// The test case failed, but only for recommended behavior, so the
+	// failure is a warning.
+	statusWarning
+)
+
+func (s outcomeStatus) String() string {
+	switch s {
+	case statusPassed:
+		return "passed"
+	case statusFailed:
+		return "failed"
+	case statusUnexpectedPass:
+		return "unexpected_pass"
+	case statusExpectedFailure:
+		return "expected_failure"
+	case statusWarning:
+		return "warning"
+	default:
+		return strconv.Itoa(int(s))
+	}
+}
+
+// isFailure returns true if the status means the run failed: either the
+// test case failed or it was expected to fail but passed.
+func (s outcomeStatus) isFailure() bool {
+	return s == statusFailed || s == statusUnexpectedPass
+}
+
+func (o *testOutcome) status() outcomeStatus {
+	var expectError bool
+	if !o.setupError {
+		expectError = o.knownFailing ||
+			(o.knownFlaky && o.actualFailure != nil)
+	}
+	switch {
+	case !expectError && o.actualFailure != nil && o.warning:
+		return statusWarning
+	case !expectError && o.actualFailure != nil:
+		return statusFailed
+	case expectError && o.actualFailure == nil:
+		return statusUnexpectedPass
+	case expectError && o.actualFailure != nil:
+		return statusExpectedFailure
+	default:
+		return statusPassed
+	}
 }
 
 type multiErrors []error
@@ -366,6 +688,48 @@ func checkHeaders(what string, expected, actual []*conformancev1.Header) multiEr
 	return errs
 }
 
+// checkHeadersPresence checks that the given actual headers include all of the
+// present names and none of the absent names.
+func checkHeadersPresence(what string, present, absent []string, actual []*conformancev1.Header) multiErrors {
+	if len(present) == 0 && len(absent) == 0 {
+		return nil
+	}
+	var errs multiErrors
+	actualNames := make(map[string]struct{}, len(actual))
+	for _, hdr := range actual {
+		actualNames[strings.ToLower(hdr.Name)] = struct{}{}
+	}
+	for _, name := range present {
+		if _, ok := actualNames[strings.ToLower(name)]; !ok {
+			errs = append(errs, fmt.Errorf("actual %s missing %q", what, strings.ToLower(name)))
+		}
+	}
+	for _, name := range absent {
+		if _, ok := actualNames[strings.ToLower(name)]; ok {
+			errs = append(errs, fmt.Errorf("actual %s include %q but it should be absent", what, strings.ToLower(name)))
+		}
+	}
+	return errs
+}
+
+// withoutHeaders returns the given headers, excluding any with the given names.
+func withoutHeaders(headers []*conformancev1.Header, names []string) []*conformancev1.Header {
+	if len(names) == 0 {
+		return headers
+	}
+	exclude := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		exclude[strings.ToLower(name)] = struct{}{}
+	}
+	results := make([]*conformancev1.Header, 0, len(headers))
+	for _, hdr := range headers {
+		if _, ok := exclude[strings.ToLower(hdr.Name)]; !ok {
+			results = append(results, hdr)
+		}
+	}
+	return results
+}
+
 func canonicalizeHeaderVals(vals []string) []string {
 	canon := make([]string, 0, len(vals))
 	for _, val := range vals {
@@ -468,7 +832,7 @@ func checkPayloads(expected, actual []*conformancev1.ConformancePayload) multiEr
 	return errs
 }
 
-func checkError(expected, actual *conformancev1.Error) multiErrors {
+func checkError(expected, actual *conformancev1.Error, matchers *conformancev1.ResponseMatchers) multiErrors {
 	switch {
 	case expected == nil && actual == nil:
 		// nothing to do
@@ -480,58 +844,160 @@ func checkError(expected, actual *conformancev1.Error) multiErrors {
 	}
 
 	var errs multiErrors
-	if expected.Code != actual.Code {
+	if allowedCodes := matchers.GetErrorCodes(); len(allowedCodes) > 0 {
+		if !contains(allowedCodes, actual.Code) {
+			names := make([]string, len(allowedCodes))
+			for i, code := range allowedCodes {
+				names[i] = fmt.Sprintf("%d (%s)", code, connect.Code(code).String())
+			}
+			errs = append(errs, fmt.Errorf("actual error code %d (%s) is not one of the allowed codes: %s",
+				actual.Code, connect.Code(actual.Code).String(), strings.Join(names, ", ")))
+		}
+	} else if expected.Code != actual.Code {
 		errs = append(errs, fmt.Errorf("actual error code %d (%s) does not match expected code %d (%s)",
 			actual.Code, connect.Code(actual.Code).String(), expected.Code, connect.Code(expected.Code).String()))
 	}
-	if expected.Message != nil && expected.GetMessage() != actual.GetMessage() {
-		errs = append(errs, fmt.Errorf("actual error message %q does not match expected message %q",
-			actual.GetMessage(), expected.GetMessage()))
+	switch {
+	case matchers.GetErrorMessageRegex() != "":
+		// The pattern is validated when test suites are loaded, but the
+		// matchers may not have come from a test suite.
+		pattern, err := regexp.Compile(matchers.GetErrorMessageRegex())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid error message regex: %w", err))
+		} else if !pattern.MatchString(actual.GetMessage()) {
+			errs = append(errs, errorMessageMismatch{fmt.Errorf("actual error message %q does not match expected pattern %q",
+				actual.GetMessage(), pattern.String())})
+		}
+	case matchers.GetErrorMessagePrefix() != "":
+		if !strings.HasPrefix(actual.GetMessage(), matchers.GetErrorMessagePrefix()) {
+			errs = append(errs, errorMessageMismatch{fmt.Errorf("actual error message %q does not start with expected prefix %q",
+				actual.GetMessage(), matchers.GetErrorMessagePrefix())})
+		}
+	case expected.Message != nil && expected.GetMessage() != actual.GetMessage():
+		errs = append(errs, errorMessageMismatch{fmt.Errorf("actual error message %q does not match expected message %q",
+			actual.GetMessage(), expected.GetMessage())})
+	}
+
+	switch matchers.GetErrorDetails() {
+	case conformancev1.ResponseMatchers_ERROR_DETAILS_MATCH_UNORDERED,
+		conformancev1.ResponseMatchers_ERROR_DETAILS_MATCH_CONTAINS:
+		errs = append(errs, checkErrorDetailsUnordered(expected.Details, actual.Details, matchers.GetErrorDetails())...)
+	default:
+		errs = append(errs, checkErrorDetailsInOrder(expected.Details, actual.Details)...)
+	}
+	return errs
+}
+
+// errorMessageMismatch is an error reported by checkError when the message of
+// the actual error does not match what is expected.
+type errorMessageMismatch struct {
+	error
+}
+
+// onlyErrorMessageMismatches returns true if the given errors are all
+// reported because the message of the actual error does not match.
+func onlyErrorMessageMismatches(errs multiErrors) bool {
+	for _, err := range errs {
+		if _, ok := err.(errorMessageMismatch); !ok { //nolint:errorlint
+			return false
+		}
 	}
-	if len(expected.Details) != len(actual.Details) {
-		// TODO: Should this be more lenient? Are we okay with a Connect implementation adding extra
-		//       error details transparently (such that the expected details would be a *subset* of
-		//       the actual details)?
+	return len(errs) > 0
+}
+
+// checkErrorDetailsInOrder checks that the actual error details are exactly
+// the expected details, in the same order.
+func checkErrorDetailsInOrder(expected, actual []*anypb.Any) multiErrors {
+	var errs multiErrors
+	if len(expected) != len(actual) {
 		errs = append(errs, fmt.Errorf("actual error contain %d details; expecting %d",
-			len(actual.Details), len(expected.Details)))
+			len(actual), len(expected)))
 	}
 	// Check as many as we can
-	length := len(expected.Details)
-	if len(actual.Details) < length {
-		length = len(actual.Details)
+	length := len(expected)
+	if len(actual) < length {
+		length = len(actual)
 	}
-	actualReqInfo := &conformancev1.ConformancePayload_RequestInfo{}
-	expectedReqInfo := &conformancev1.ConformancePayload_RequestInfo{}
 	for i := 0; i < length; i++ {
-		// TODO: Should this be more lenient? Are we okay with details getting re-ordered?
-		//       An alternative might be to create a map keyed by type, and for each type
-		//       remove expected messages as they are matched against actual ones.
-		actualDetails := actual.Details[i]
-		expectedDetails := expected.Details[i]
-
-		// If the error details is a RequestInfo, then verify equality using the checkRequestInfo function
-		// Otherwise, just do a straight diff of the two
-		if actualDetails.MessageIs(actualReqInfo) && expectedDetails.MessageIs(expectedReqInfo) {
-			// If unmarshalling fails for either detail, add it to the errors and move on to the next detail
-			if err := actualDetails.UnmarshalTo(actualReqInfo); err != nil {
-				errs = append(errs, fmt.Errorf("unable to unmarshal request info from actual error detail %s", actualDetails.MessageName()))
-				continue
+		errs = append(errs, checkErrorDetail(i+1, expected[i], actual[i])...)
+	}
+	return errs
+}
+
+// checkErrorDetailsUnordered checks that every expected error detail matches
+// a distinct actual error detail, in any order. With mode
+// ERROR_DETAILS_MATCH_UNORDERED, it also checks that there are no other
+// actual error details.
+func checkErrorDetailsUnordered(expected, actual []*anypb.Any, mode conformancev1.ResponseMatchers_ErrorDetailsMatch) multiErrors {
+	var errs multiErrors
+	if mode == conformancev1.ResponseMatchers_ERROR_DETAILS_MATCH_UNORDERED && len(expected) != len(actual) {
+		errs = append(errs, fmt.Errorf("actual error contain %d details; expecting %d",
+			len(actual), len(expected)))
+	}
+	// An expected detail may match more than one actual detail, so taking the
+	// first match for each could leave a later expected detail unmatched.
+	// Instead, this finds a maximum matching between them.
+	matches := make([][]int, len(expected))
+	for i, expectedDetail := range expected {
+		for j, actualDetail := range actual {
+			if len(checkErrorDetail(i+1, expectedDetail, actualDetail)) == 0 {
+				matches[i] = append(matches[i], j)
 			}
-			if err := expectedDetails.UnmarshalTo(expectedReqInfo); err != nil {
-				errs = append(errs, fmt.Errorf("unable to unmarshal request info from expected error detail %s", expectedDetails.MessageName()))
+		}
+	}
+	// The index of the expected detail matched to each actual detail, or -1.
+	matchedTo := make([]int, len(actual))
+	for j := range matchedTo {
+		matchedTo[j] = -1
+	}
+	// assign tries to match the given expected detail, re-assigning other
+	// expected details to make room for it if necessary.
+	var assign func(i int, visited []bool) bool
+	assign = func(i int, visited []bool) bool {
+		for _, j := range matches[i] {
+			if visited[j] {
 				continue
 			}
-			errs = append(errs, checkRequestInfo(expectedReqInfo, actualReqInfo, true)...)
-		} else {
-			if diff := cmp.Diff(expectedDetails, actualDetails, protocmp.Transform()); diff != "" {
-				errs = append(errs, fmt.Errorf("actual error detail #%d does not match expected error detail: - wanted, + got\n%s",
-					i+1, diff))
+			visited[j] = true
+			if matchedTo[j] == -1 || assign(matchedTo[j], visited) {
+				matchedTo[j] = i
+				return true
 			}
 		}
+		return false
+	}
+	for i, expectedDetail := range expected {
+		if !assign(i, make([]bool, len(actual))) {
+			errs = append(errs, fmt.Errorf("expected error detail #%d (%s) does not match any actual error detail",
+				i+1, expectedDetail.MessageName()))
+		}
 	}
 	return errs
 }
 
+// checkErrorDetail compares the given expected and actual error details. The
+// given number identifies the detail in error messages.
+func checkErrorDetail(detailNum int, expected, actual *anypb.Any) multiErrors {
+	// If the error details is a RequestInfo, then verify equality using the checkRequestInfo function
+	// Otherwise, just do a straight diff of the two
+	actualReqInfo := &conformancev1.ConformancePayload_RequestInfo{}
+	expectedReqInfo := &conformancev1.ConformancePayload_RequestInfo{}
+	if actual.MessageIs(actualReqInfo) && expected.MessageIs(expectedReqInfo) {
+		if err := actual.UnmarshalTo(actualReqInfo); err != nil {
+			return multiErrors{fmt.Errorf("unable to unmarshal request info from actual error detail %s", actual.MessageName())}
+		}
+		if err := expected.UnmarshalTo(expectedReqInfo); err != nil {
+			return multiErrors{fmt.Errorf("unable to unmarshal request info from expected error detail %s", expected.MessageName())}
+		}
+		return checkRequestInfo(expectedReqInfo, actualReqInfo, true)
+	}
+	if diff := cmp.Diff(expected, actual, protocmp.Transform()); diff != "" {
+		return multiErrors{fmt.Errorf("actual error detail #%d does not match expected error detail: - wanted, + got\n%s",
+			detailNum, diff)}
+	}
+	return nil
+}
+
 func indent(s string) string {
 	lines := strings.Split(s, "\n")
 	for i, line := range lines {
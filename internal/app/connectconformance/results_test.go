@@ -712,6 +712,273 @@ func TestResults_Assert_ReportsAllErrors(t *testing.T) {
 	}
 }
 
+func TestResults_Assert_ResponseMatchers(t *testing.T) {
+	t.Parallel()
+	errorWithDetails := `{
+		"error": {
+			"code": 5,
+			"message": "resource foo not found",
+			"details": [
+				{"@type": "/google.protobuf.Int32Value", "value": 1},
+				{"@type": "/google.protobuf.Int32Value", "value": 2}
+			]
+		}
+	}`
+	testCases := []struct {
+		name            string
+		expected        string
+		actual          string
+		matchers        string
+		invalidMatchers bool
+		expectedErrors  []string
+	}{
+		{
+			name:     "allowed codes",
+			expected: `{"error": {"code": 5}}`,
+			actual:   `{"error": {"code": 9}}`,
+			matchers: `{"error_codes": ["CODE_NOT_FOUND", "CODE_FAILED_PRECONDITION"]}`,
+		},
+		{
+			name:     "allowed codes mismatch",
+			expected: `{"error": {"code": 5}}`,
+			actual:   `{"error": {"code": 2}}`,
+			matchers: `{"error_codes": ["CODE_NOT_FOUND", "CODE_FAILED_PRECONDITION"]}`,
+			expectedErrors: []string{
+				"actual error code 2 (unknown) is not one of the allowed codes: 5 (not_found), 9 (failed_precondition)",
+			},
+		},
+		{
+			name:     "message regex",
+			expected: `{"error": {"code": 5, "message": "not found"}}`,
+			actual:   `{"error": {"code": 5, "message": "resource foo not found"}}`,
+			matchers: `{"error_message_regex": "^resource [a-z]+ not found$"}`,
+		},
+		{
+			name:            "invalid message regex",
+			expected:        `{"error": {"code": 5, "message": "not found"}}`,
+			actual:          `{"error": {"code": 5, "message": "resource foo not found"}}`,
+			matchers:        `{"error_message_regex": "resource [a-z"}`,
+			invalidMatchers: true,
+			expectedErrors: []string{
+				"invalid error message regex: error parsing regexp: missing closing ]: `[a-z`",
+			},
+		},
+		{
+			name:     "message regex mismatch",
+			expected: `{"error": {"code": 5, "message": "not found"}}`,
+			actual:   `{"error": {"code": 5, "message": "resource Foo not found"}}`,
+			matchers: `{"error_message_regex": "^resource [a-z]+ not found$"}`,
+			expectedErrors: []string{
+				`actual error message "resource Foo not found" does not match expected pattern "^resource [a-z]+ not found$"`,
+			},
+		},
+		{
+			name:     "message prefix",
+			expected: `{"error": {"code": 5, "message": "resource"}}`,
+			actual:   `{"error": {"code": 5, "message": "resource foo not found"}}`,
+			matchers: `{"error_message_prefix": "resource "}`,
+		},
+		{
+			name:     "message prefix mismatch",
+			expected: `{"error": {"code": 5, "message": "resource"}}`,
+			actual:   `{"error": {"code": 5, "message": "foo not found"}}`,
+			matchers: `{"error_message_prefix": "resource "}`,
+			expectedErrors: []string{
+				`actual error message "foo not found" does not start with expected prefix "resource "`,
+			},
+		},
+		{
+			name:     "details unordered",
+			expected: errorWithDetails,
+			actual: `{
+				"error": {
+					"code": 5,
+					"message": "resource foo not found",
+					"details": [
+						{"@type": "/google.protobuf.Int32Value", "value": 2},
+						{"@type": "/google.protobuf.Int32Value", "value": 1}
+					]
+				}
+			}`,
+			matchers: `{"error_details": "ERROR_DETAILS_MATCH_UNORDERED"}`,
+		},
+		{
+			name:     "details unordered with extra",
+			expected: errorWithDetails,
+			actual: `{
+				"error": {
+					"code": 5,
+					"message": "resource foo not found",
+					"details": [
+						{"@type": "/google.protobuf.Int32Value", "value": 2},
+						{"@type": "/google.protobuf.Int32Value", "value": 3},
+						{"@type": "/google.protobuf.Int32Value", "value": 1}
+					]
+				}
+			}`,
+			matchers: `{"error_details": "ERROR_DETAILS_MATCH_UNORDERED"}`,
+			expectedErrors: []string{
+				"actual error contain 3 details; expecting 2",
+			},
+		},
+		{
+			// The first expected detail matches both actual details, but the second
+			// only matches the first. So the first expected detail must not take it.
+			name: "details unordered with overlapping matchers",
+			expected: `{
+				"error": {
+					"code": 5,
+					"details": [
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ConformancePayload.RequestInfo",
+							"requestHeaders": [{"name": "abc", "value": ["1"]}]
+						},
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ConformancePayload.RequestInfo",
+							"requestHeaders": [{"name": "abc", "value": ["1"]}, {"name": "def", "value": ["2"]}]
+						}
+					]
+				}
+			}`,
+			actual: `{
+				"error": {
+					"code": 5,
+					"details": [
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ConformancePayload.RequestInfo",
+							"requestHeaders": [{"name": "abc", "value": ["1"]}, {"name": "def", "value": ["2"]}]
+						},
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ConformancePayload.RequestInfo",
+							"requestHeaders": [{"name": "abc", "value": ["1"]}]
+						}
+					]
+				}
+			}`,
+			matchers: `{"error_details": "ERROR_DETAILS_MATCH_UNORDERED"}`,
+		},
+		{
+			name:     "details contains",
+			expected: errorWithDetails,
+			actual: `{
+				"error": {
+					"code": 5,
+					"message": "resource foo not found",
+					"details": [
+						{"@type": "/google.protobuf.Int32Value", "value": 2},
+						{"@type": "/google.protobuf.Int32Value", "value": 3},
+						{"@type": "/google.protobuf.Int32Value", "value": 1}
+					]
+				}
+			}`,
+			matchers: `{"error_details": "ERROR_DETAILS_MATCH_CONTAINS"}`,
+		},
+		{
+			name:     "details contains missing",
+			expected: errorWithDetails,
+			actual: `{
+				"error": {
+					"code": 5,
+					"message": "resource foo not found",
+					"details": [
+						{"@type": "/google.protobuf.Int32Value", "value": 2},
+						{"@type": "/google.protobuf.Int32Value", "value": 2}
+					]
+				}
+			}`,
+			matchers: `{"error_details": "ERROR_DETAILS_MATCH_CONTAINS"}`,
+			expectedErrors: []string{
+				"expected error detail #1 (google.protobuf.Int32Value) does not match any actual error detail",
+			},
+		},
+		{
+			name: "headers present and absent",
+			expected: `{
+				"response_headers": [{"name": "abc", "value": ["xyz"]}],
+				"response_trailers": [{"name": "def", "value": ["123"]}]
+			}`,
+			actual: `{
+				"response_headers": [{"name": "Abc", "value": ["anything"]}],
+				"response_trailers": [{"name": "def", "value": ["whatever"]}, {"name": "ghi", "value": ["1"]}]
+			}`,
+			matchers: `{
+				"response_headers_present": ["abc"],
+				"response_headers_absent": ["ghi"],
+				"response_trailers_present": ["def", "ghi"],
+				"response_trailers_absent": ["abc"]
+			}`,
+		},
+		{
+			name:     "headers present and absent mismatch",
+			expected: `{}`,
+			actual: `{
+				"response_headers": [{"name": "ghi", "value": ["1"]}],
+				"response_trailers": [{"name": "abc", "value": ["1"]}]
+			}`,
+			matchers: `{
+				"response_headers_present": ["abc"],
+				"response_headers_absent": ["ghi"],
+				"response_trailers_present": ["def"],
+				"response_trailers_absent": ["abc"]
+			}`,
+			expectedErrors: []string{
+				`actual response headers missing "abc"`,
+				`actual response headers include "ghi" but it should be absent`,
+				`actual response trailers missing "def"`,
+				`actual response trailers include "abc" but it should be absent`,
+			},
+		},
+		{
+			name:     "unary error headers may be reported as trailers",
+			expected: `{"error": {"code": 5}}`,
+			actual: `{
+				"error": {"code": 5},
+				"response_trailers": [{"name": "abc", "value": ["1"]}]
+			}`,
+			matchers: `{"response_headers_present": ["abc"]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			results := newResults(&testTrie{}, &testTrie{}, nil)
+
+			expected := &conformancev1.TestCase{
+				Request:          &conformancev1.ClientCompatRequest{StreamType: conformancev1.StreamType_STREAM_TYPE_UNARY},
+				ExpectedResponse: &conformancev1.ClientResponseResult{},
+				ResponseMatchers: &conformancev1.ResponseMatchers{},
+			}
+			err := protojson.Unmarshal(([]byte)(testCase.expected), expected.ExpectedResponse)
+			require.NoError(t, err)
+			err = protojson.Unmarshal(([]byte)(testCase.matchers), expected.ResponseMatchers)
+			require.NoError(t, err)
+			if !testCase.invalidMatchers {
+				require.NoError(t, validateResponseMatchers(expected.ResponseMatchers))
+			}
+			actual := &conformancev1.ClientResponseResult{}
+			err = protojson.Unmarshal(([]byte)(testCase.actual), actual)
+			require.NoError(t, err)
+
+			results.assert(testCase.name, expected, actual)
+			err = results.outcomes[testCase.name].actualFailure
+			if len(testCase.expectedErrors) == 0 {
+				require.NoError(t, err)
+			} else {
+				var errs multiErrors
+				if !errors.As(err, &errs) {
+					errs = multiErrors{err}
+				}
+				require.Len(t, errs, len(testCase.expectedErrors), "%v", err)
+				for i := range errs {
+					assert.EqualError(t, errs[i], testCase.expectedErrors[i])
+				}
+			}
+		})
+	}
+}
+
 func TestResults_ServerSideband(t *testing.T) {
 	t.Parallel()
 	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
@@ -720,9 +987,9 @@ func TestResults_ServerSideband(t *testing.T) {
 	results.setOutcome("foo/bar/3", false, nil)
 	results.setOutcome("known-to-fail/1", false, nil)
 	results.setOutcome("known-to-fail/2", false, errors.New("fail"))
-	results.recordSideband("foo/bar/2", "something awkward in wire format")
-	results.recordSideband("foo/bar/3", "something awkward in wire format")
-	results.recordSideband("known-to-fail/1", "something awkward in wire format")
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/2", Message: "something awkward in wire format"})
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/3", Message: "something awkward in wire format"})
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "known-to-fail/1", Message: "something awkward in wire format"})
 
 	logger := &internal.SimplePrinter{}
 	success := results.report(logger)
@@ -735,6 +1002,58 @@ func TestResults_ServerSideband(t *testing.T) {
 	require.Equal(t, lines[3], "INFO: known-to-fail/2 failed (as expected):\n\tfail\n")
 }
 
+func TestResults_ServerSideband_CountsTowardMaxFailures(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.maxFailures = 2
+	var stopped bool
+	results.stopDispatch = func() { stopped = true }
+	status := func(testCase string) outcomeStatus {
+		outcome := results.outcomes[testCase]
+		return outcome.status()
+	}
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, nil)
+	results.setOutcome("foo/bar/3", false, nil)
+	// Feedback about recommended behavior is only a warning.
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/1", Message: "something awkward", Severity: conformancev1.Severity_SEVERITY_SHOULD})
+	assert.Equal(t, statusWarning, status("foo/bar/1"))
+	// Feedback that arrives before the outcome is merged when it is set.
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/4", Message: "something awkward in wire format"})
+	results.setOutcome("foo/bar/4", false, nil)
+	assert.Equal(t, statusFailed, status("foo/bar/4"))
+	assert.False(t, stopped)
+	// Feedback that arrives after the outcome is also counted.
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/2", Message: "something awkward in wire format"})
+	assert.Equal(t, statusFailed, status("foo/bar/2"))
+	assert.True(t, stopped)
+	assert.Equal(t, 2, results.failures)
+	// More feedback for a failed test case is not counted again.
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "foo/bar/2", Message: "something else awkward"})
+	assert.Equal(t, 2, results.failures)
+}
+
+func TestResults_UnexpectedPass_CountsTowardMaxFailures(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.maxFailures = 2
+	results.progress = newProgress(ProgressLog, nil, &internal.SimplePrinter{})
+	var stopped bool
+	results.stopDispatch = func() { stopped = true }
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("known-to-fail/1", false, errors.New("fail"))
+	// A known failure that passes is counted the same way by the progress
+	// display and by the maximum number of failures.
+	results.setOutcome("known-to-fail/2", false, nil)
+	assert.Equal(t, 1, results.failures)
+	assert.Equal(t, 1, results.progress.numFailed)
+	assert.False(t, stopped)
+	results.setOutcome("foo/bar/2", false, errors.New("fail"))
+	assert.Equal(t, 2, results.failures)
+	assert.Equal(t, 2, results.progress.numFailed)
+	assert.True(t, stopped)
+}
+
 func TestResults_Report(t *testing.T) {
 	t.Parallel()
 	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
@@ -786,6 +1105,258 @@ func TestResults_Report(t *testing.T) {
 	require.True(t, success)
 }
 
+func TestResults_Report_FailuresByTag(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.addTestCases([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/1"}, Tags: []string{"cancellation", "streaming"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/2"}, Tags: []string{"streaming"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/3"}, Tags: []string{"trailers"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "known-to-fail/1"}, Tags: []string{"trailers"}},
+	})
+	results.setOutcome("foo/1", false, errors.New("ruh roh"))
+	results.setOutcome("foo/2", false, errors.New("ruh roh"))
+	results.setOutcome("foo/3", false, nil)
+	results.setOutcome("known-to-fail/1", false, errors.New("ruh roh"))
+	logger := &internal.SimplePrinter{}
+	require.False(t, results.report(logger))
+	messages := strings.Join(logger.Messages, "")
+	// Expected failures are not counted.
+	assert.Contains(t, messages, "\nFailures by tag:\n  streaming: 2\n  cancellation: 1\n")
+	assert.NotContains(t, messages, "trailers")
+}
+
+func TestResults_Severity(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		strict       bool
+		expectPassed bool
+		expectErrs   []string
+	}{
+		{
+			name:         "lenient",
+			expectPassed: true,
+			expectErrs: []string{
+				"FAILED: must/3:\n\tsomething awry; ruh roh\n",
+				"FAILED: should/3:\n\tsomething awry; ruh roh\n",
+				"WARNING: must/2:\n\tsomething awkward\n",
+				"WARNING: should/1:\n\truh roh\n",
+				"WARNING: should/2:\n\tsomething awkward; ruh roh\n",
+			},
+		},
+		{
+			name:   "strict",
+			strict: true,
+			expectErrs: []string{
+				"FAILED: must/2:\n\tsomething awkward\n",
+				"FAILED: must/3:\n\tsomething awry; ruh roh\n",
+				"FAILED: should/1:\n\truh roh\n",
+				"FAILED: should/2:\n\tsomething awkward; ruh roh\n",
+				"FAILED: should/3:\n\tsomething awry; ruh roh\n",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+			results.strict = testCase.strict
+			must, should := conformancev1.Severity_SEVERITY_MUST, conformancev1.Severity_SEVERITY_SHOULD
+			results.addTestCases([]*conformancev1.TestCase{
+				{Request: &conformancev1.ClientCompatRequest{TestName: "must/1"}, Severity: must},
+				{Request: &conformancev1.ClientCompatRequest{TestName: "must/2"}},
+				{Request: &conformancev1.ClientCompatRequest{TestName: "must/3"}, Severity: must},
+				{Request: &conformancev1.ClientCompatRequest{TestName: "should/1"}, Severity: should},
+				{Request: &conformancev1.ClientCompatRequest{TestName: "should/2"}, Severity: should},
+				{Request: &conformancev1.ClientCompatRequest{TestName: "should/3"}, Severity: should},
+			})
+			results.setOutcome("must/1", false, nil)
+			// Feedback about recommendations is only a warning.
+			results.setOutcome("must/2", false, nil)
+			results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "must/2", Message: "something awkward", Severity: conformancev1.Severity_SEVERITY_SHOULD})
+			results.setOutcome("must/3", false, errors.New("ruh roh"))
+			results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "must/3", Message: "something awry"})
+			results.setOutcome("should/1", false, errors.New("ruh roh"))
+			results.setOutcome("should/2", false, errors.New("ruh roh"))
+			results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "should/2", Message: "something awkward", Severity: conformancev1.Severity_SEVERITY_SHOULD})
+			// Feedback about requirements is a failure, even for SHOULD-level test cases.
+			results.setOutcome("should/3", false, errors.New("ruh roh"))
+			results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "should/3", Message: "something awry"})
+
+			logger := &internal.SimplePrinter{}
+			require.False(t, results.report(logger))
+			assert.Equal(t, testCase.expectErrs, errorMessages(logger.Messages))
+			messages := strings.Join(logger.Messages, "")
+			if testCase.strict {
+				assert.NotContains(t, messages, "Warnings")
+				assert.Contains(t, messages, "1 passed, 5 failed\n")
+			} else {
+				assert.Contains(t, messages, "\nWarnings (recommended behavior that is not required):\n")
+				assert.Contains(t, messages, "1 passed, 2 failed\n(Another 3 had warnings; use --strict to treat them as failures.)\n")
+			}
+		})
+	}
+}
+
+func TestResults_Severity_WarningsOnly(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.addTestCases([]*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "should/1"}, Severity: conformancev1.Severity_SEVERITY_SHOULD},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "should/2"}, Severity: conformancev1.Severity_SEVERITY_SHOULD},
+	})
+	results.setOutcome("should/1", false, errors.New("ruh roh"))
+	results.setOutcome("should/2", false, nil)
+	logger := &internal.SimplePrinter{}
+	require.True(t, results.report(logger))
+	outcome := results.outcomes["should/1"]
+	assert.Equal(t, statusWarning, outcome.status())
+	// Warnings are not recorded as known failures.
+	assert.Empty(t, results.knownFailingPatternsLocked(nil))
+}
+
+func TestResults_Severity_ErrorMessage(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		severity       conformancev1.Severity
+		matchers       string
+		strict         bool
+		actual         string
+		expectedStatus outcomeStatus
+	}{
+		{
+			name:           "message matches",
+			matchers:       `{"errorMessageSeverity": "SEVERITY_SHOULD"}`,
+			actual:         `{"error": {"code": 5, "message": "not found"}}`,
+			expectedStatus: statusPassed,
+		},
+		{
+			name:           "message mismatch with test case severity",
+			actual:         `{"error": {"code": 5, "message": "oops"}}`,
+			expectedStatus: statusFailed,
+		},
+		{
+			name:           "message mismatch with recommended message",
+			matchers:       `{"errorMessageSeverity": "SEVERITY_SHOULD"}`,
+			actual:         `{"error": {"code": 5, "message": "oops"}}`,
+			expectedStatus: statusWarning,
+		},
+		{
+			name:           "prefix mismatch with recommended message",
+			matchers:       `{"errorMessageSeverity": "SEVERITY_SHOULD", "errorMessagePrefix": "not"}`,
+			actual:         `{"error": {"code": 5, "message": "oops"}}`,
+			expectedStatus: statusWarning,
+		},
+		{
+			name:           "message mismatch with recommended message in strict mode",
+			matchers:       `{"errorMessageSeverity": "SEVERITY_SHOULD"}`,
+			strict:         true,
+			actual:         `{"error": {"code": 5, "message": "oops"}}`,
+			expectedStatus: statusFailed,
+		},
+		{
+			name:           "code and message mismatch with recommended message",
+			matchers:       `{"errorMessageSeverity": "SEVERITY_SHOULD"}`,
+			actual:         `{"error": {"code": 3, "message": "oops"}}`,
+			expectedStatus: statusFailed,
+		},
+		{
+			name:           "message mismatch with required message",
+			severity:       conformancev1.Severity_SEVERITY_SHOULD,
+			matchers:       `{"errorMessageSeverity": "SEVERITY_MUST"}`,
+			actual:         `{"error": {"code": 5, "message": "oops"}}`,
+			expectedStatus: statusFailed,
+		},
+		{
+			name:           "code mismatch in recommended test case",
+			severity:       conformancev1.Severity_SEVERITY_SHOULD,
+			matchers:       `{"errorMessageSeverity": "SEVERITY_MUST"}`,
+			actual:         `{"error": {"code": 3, "message": "not found"}}`,
+			expectedStatus: statusWarning,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			results := newResults(&testTrie{}, &testTrie{}, nil)
+			results.strict = testCase.strict
+			definition := &conformancev1.TestCase{
+				Request: &conformancev1.ClientCompatRequest{
+					TestName:   "foo/bar",
+					StreamType: conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				ExpectedResponse: &conformancev1.ClientResponseResult{
+					Error: &conformancev1.Error{Code: conformancev1.Code_CODE_NOT_FOUND, Message: proto.String("not found")},
+				},
+				ResponseMatchers: &conformancev1.ResponseMatchers{},
+				Severity:         testCase.severity,
+			}
+			if testCase.matchers != "" {
+				require.NoError(t, protojson.Unmarshal([]byte(testCase.matchers), definition.ResponseMatchers))
+			}
+			actual := &conformancev1.ClientResponseResult{}
+			require.NoError(t, protojson.Unmarshal([]byte(testCase.actual), actual))
+			results.addTestCases([]*conformancev1.TestCase{definition})
+			results.assert("foo/bar", definition, actual)
+			outcome := results.outcomes["foo/bar"]
+			assert.Equal(t, testCase.expectedStatus, outcome.status())
+		})
+	}
+}
+
+func TestResults_RetryFlaky(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	retryIfFlaky := func(testName string) bool {
+		retries := results.retryFlaky([]*conformancev1.TestCase{
+			{Request: &conformancev1.ClientCompatRequest{TestName: testName}},
+		})
+		return len(retries) == 1
+	}
+
+	// No retries configured.
+	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
+	require.False(t, retryIfFlaky("known-to-flake/1"))
+
+	results.flakyRetries = 2
+	// Not flaky, passed, or setup errors? No retry.
+	results.setOutcome("foo/bar/1", false, errors.New("ruh roh"))
+	require.False(t, retryIfFlaky("foo/bar/1"))
+	results.setOutcome("known-to-flake/2", false, nil)
+	require.False(t, retryIfFlaky("known-to-flake/2"))
+	results.setOutcome("known-to-flake/3", true, errors.New("ruh roh"))
+	require.False(t, retryIfFlaky("known-to-flake/3"))
+
+	// Failures are retried, up to the limit.
+	require.True(t, retryIfFlaky("known-to-flake/1"))
+	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
+	require.True(t, retryIfFlaky("known-to-flake/1"))
+	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
+	require.False(t, retryIfFlaky("known-to-flake/1"))
+
+	// Sideband feedback counts as a failure.
+	results.setOutcome("known-to-flake/4", false, nil)
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "known-to-flake/4", Message: "something awry"})
+	require.True(t, retryIfFlaky("known-to-flake/4"))
+	results.setOutcome("known-to-flake/4", false, nil)
+	// Late feedback from the first attempt is not attributed to the retry.
+	results.recordSidebandForAttempt(1, &internal.Feedback{TestName: "known-to-flake/4", Message: "something awry"})
+	require.False(t, retryIfFlaky("known-to-flake/4"))
+
+	logger := &internal.SimplePrinter{}
+	require.False(t, results.report(logger))
+	assert.Equal(t, []string{
+		"FAILED: foo/bar/1:\n\truh roh\n",
+		"INFO: known-to-flake/1 failed (as expected) after 3 attempts:\n\truh roh\n",
+		"FAILED: known-to-flake/3:\n\truh roh\n",
+		"INFO: known-to-flake/4 passed after 2 attempts\n",
+	}, errorMessages(logger.Messages))
+}
+
 func TestCanonicalizeHeaderVals(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -853,7 +1424,7 @@ func makeKnownFlaky() *testTrie {
 func errorMessages(msgs []string) []string {
 	var errs []string
 	for _, msg := range msgs {
-		if strings.HasPrefix(msg, "FAILED: ") || strings.HasPrefix(msg, "INFO: ") {
+		if strings.HasPrefix(msg, "FAILED: ") || strings.HasPrefix(msg, "INFO: ") || strings.HasPrefix(msg, "WARNING: ") {
 			errs = append(errs, msg)
 		}
 	}
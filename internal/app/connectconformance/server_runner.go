@@ -17,12 +17,15 @@ package connectconformance
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -42,15 +45,80 @@ import (
 // If isReferenceServer is true, then the server's stderr will be examined as well, to
 // record out-of-band feedback about the client requests.
 //
-//nolint:gocyclo
+// The given description is used to identify the server when recording how long it
+// took to start and to run its test cases.
+//
+// The given codec is used to write the server request to the server process's stdin
+// and to read its response from the process's stdout.
+//
+// Known-flaky test cases that fail are retried, up to the number of retries configured
+// in results. Retries are only decided once the server process has stopped, so that all
+// of its feedback about the test cases has been recorded, and they are run against a new
+// server process, in case the failure was caused by the server's state.
 func runTestCasesForServer(
 	ctx context.Context,
 	isReferenceClient bool,
 	isReferenceServer bool,
 	meta serverInstance,
+	description string,
+	testCases []*conformancev1.TestCase,
+	clientCreds *conformancev1.ClientCompatRequest_TLSCreds,
+	startServer processStarter,
+	codec internal.Codec,
+	logPrinter internal.Printer,
+	errPrinter internal.Printer,
+	results *testResults,
+	client clientRunner,
+	tracer *tracer.Tracer,
+	logEach bool,
+) {
+	for attempt := 1; len(testCases) > 0; attempt++ {
+		attemptDescription := description
+		if attempt > 1 {
+			attemptDescription = fmt.Sprintf("%s (attempt %d)", description, attempt)
+			if logEach {
+				logPrinter.Printf("Retrying %d flaky test case(s) with a new server for %s...", len(testCases), description)
+			}
+		}
+		runTestCasesForServerProcess(
+			ctx,
+			isReferenceClient,
+			isReferenceServer,
+			meta,
+			attemptDescription,
+			attempt,
+			testCases,
+			clientCreds,
+			startServer,
+			codec,
+			logPrinter,
+			errPrinter,
+			results,
+			client,
+			tracer,
+			logEach,
+		)
+		testCases = results.retryFlaky(testCases)
+	}
+}
+
+// runTestCasesForServerProcess starts a single server process and runs the given test
+// cases against it, as one attempt of runTestCasesForServer. Feedback from the reference
+// server or reference client is recorded for the given attempt, so that feedback from an
+// earlier attempt is never attributed to a retry.
+//
+//nolint:gocyclo
+func runTestCasesForServerProcess(
+	ctx context.Context,
+	isReferenceClient bool,
+	isReferenceServer bool,
+	meta serverInstance,
+	description string,
+	attempt int,
 	testCases []*conformancev1.TestCase,
 	clientCreds *conformancev1.ClientCompatRequest_TLSCreds,
 	startServer processStarter,
+	codec internal.Codec,
 	logPrinter internal.Printer,
 	errPrinter internal.Printer,
 	results *testResults,
@@ -65,6 +133,7 @@ func runTestCasesForServer(
 
 	procCtx, procCancel := context.WithCancel(ctx)
 	defer procCancel()
+	serverStart := time.Now()
 	serverProcess, err := startServer(procCtx, isReferenceServer)
 	if err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error starting server: %w", err))
@@ -86,12 +155,12 @@ func runTestCasesForServer(
 				str := strings.TrimSpace(origLine)
 				if str != "" {
 					var isSideband bool
-					parts := strings.SplitN(str, ": ", 2)
-					if len(parts) == 2 {
-						if _, ok := testCaseNameSet[parts[0]]; ok {
-							// appears to be valid message in the form "test case: error message"
+					var feedback internal.Feedback
+					if err := json.Unmarshal([]byte(str), &feedback); err == nil {
+						if _, ok := testCaseNameSet[feedback.TestName]; ok {
+							// appears to be valid feedback about a test case
 							isSideband = true
-							results.recordSideband(parts[0], parts[1])
+							results.recordSidebandForAttempt(attempt, &feedback)
 						}
 					}
 					if !isSideband {
@@ -112,7 +181,7 @@ func runTestCasesForServer(
 	}
 
 	// Write server request.
-	err = internal.WriteDelimitedMessage(serverProcess.stdin, &conformancev1.ServerCompatRequest{
+	err = codec.NewEncoder(serverProcess.stdin).Encode(&conformancev1.ServerCompatRequest{
 		Protocol:      meta.protocol,
 		HttpVersion:   meta.httpVersion,
 		UseTls:        meta.useTLS,
@@ -132,16 +201,37 @@ func runTestCasesForServer(
 
 	// Read response.
 	var resp conformancev1.ServerCompatResponse
-	err = internal.ReadDelimitedMessage(serverProcess.stdout, &resp)
+	err = codec.NewDecoder(serverProcess.stdout).DecodeNext(&resp)
 	if err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error reading server response: %w", err))
 		return
 	}
+	// Nothing else is expected on stdout, but discard anything else written,
+	// like the newline after a JSON message, so that the server never blocks
+	// writing it.
+	go func() {
+		_, _ = io.Copy(io.Discard, serverProcess.stdout)
+	}()
+	startupDuration := time.Since(serverStart)
+	defer func() {
+		results.recordServerTiming(serverTiming{
+			name:     description,
+			numCases: len(testCases),
+			startup:  startupDuration,
+			total:    time.Since(serverStart),
+		})
+	}()
 
 	// Send all test cases to the client.
 	var wg sync.WaitGroup
 	for i := range testCases {
 		testCase := testCases[i]
+		if results.dispatchStopped() {
+			// reached maximum number of failures: skip remaining tests,
+			// but still wait for those already sent
+			results.skip(testCases[i:])
+			break
+		}
 		if procCtx.Err() != nil {
 			// server crashed: mark remaining tests
 			err := errors.New("server process terminated unexpectedly")
@@ -178,6 +268,8 @@ func runTestCasesForServer(
 				{Name: "x-expect-codec", Value: []string{strconv.Itoa(int(req.Codec))}},
 				{Name: "x-expect-compression", Value: []string{strconv.Itoa(int(req.Compression))}},
 				{Name: "x-expect-tls", Value: []string{strconv.FormatBool(len(resp.PemCert) > 0)}},
+				// Raw requests are sent as is, so they need not have a user-agent.
+				{Name: "x-expect-user-agent", Value: []string{strconv.FormatBool(req.RawRequest == nil)}},
 			}
 			if clientCreds != nil {
 				extraHeaders = append(
@@ -196,8 +288,10 @@ func runTestCasesForServer(
 		if logEach {
 			logPrinter.Printf("Sending request for %q...", req.TestName)
 		}
+		sent := time.Now()
 		err := client.sendRequest(req, func(name string, resp *conformancev1.ClientCompatResponse, err error) {
 			defer wg.Done()
+			results.recordTiming(name, sent, time.Now())
 			if logEach {
 				logPrinter.Printf("Received response for %q...", req.TestName)
 			}
@@ -211,7 +305,14 @@ func runTestCasesForServer(
 			}
 			if isReferenceClient && resp.GetResponse() != nil {
 				for _, msg := range resp.GetResponse().Feedback {
-					results.recordSideband(resp.TestName, msg)
+					results.recordSidebandForAttempt(attempt, &internal.Feedback{TestName: resp.TestName, Message: msg})
+				}
+				for _, msg := range resp.GetResponse().RecommendationFeedback {
+					results.recordSidebandForAttempt(attempt, &internal.Feedback{
+						TestName: resp.TestName,
+						Message:  msg,
+						Severity: conformancev1.Severity_SEVERITY_SHOULD,
+					})
 				}
 			}
 		})
@@ -18,17 +18,22 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 
 	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/referenceserver"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
@@ -149,9 +154,10 @@ func TestRunTestCasesForServer(t *testing.T) {
 			name:              "server sends sideband info",
 			isReferenceServer: true,
 			svrErrorReader: strings.NewReader(strings.Join([]string{
-				"TestSuite1/testcase1: server didn't like this request",
+				`{"testName": "TestSuite1/testcase1", "message": "server didn't like this request"}`,
 				"This line is ignored because it doesn't look right",
-				"Blah:Blah/blah: ignored because this isn't a valid test case name",
+				"TestSuite2/testcase2: ignored because it isn't JSON",
+				`{"testName": "Blah:Blah/blah", "message": "ignored because this isn't a valid test case name"}`,
 			}, "\n")),
 			expectResults: map[string]bool{
 				"TestSuite1/testcase1": false, // error due to sideband info
@@ -244,6 +250,7 @@ func TestRunTestCasesForServer(t *testing.T) {
 						&conformancev1.Header{Name: "x-expect-codec", Value: []string{"0"}},
 						&conformancev1.Header{Name: "x-expect-compression", Value: []string{"0"}},
 						&conformancev1.Header{Name: "x-expect-tls", Value: []string{"false"}},
+						&conformancev1.Header{Name: "x-expect-user-agent", Value: []string{"true"}},
 					)
 				}
 				copyOfRequests[i] = req
@@ -267,9 +274,11 @@ func TestRunTestCasesForServer(t *testing.T) {
 				!testCase.isReferenceServer,
 				testCase.isReferenceServer,
 				svrInstance,
+				"test server",
 				testCaseData,
 				nil, // TODO: client cert
 				hookedProcess,
+				internal.NewCodec(false),
 				discardPrinter{},
 				discardPrinter{},
 				results,
@@ -301,6 +310,353 @@ func TestRunTestCasesForServer(t *testing.T) {
 	}
 }
 
+func TestRunTestCasesForServer_RetriesFlaky(t *testing.T) {
+	t.Parallel()
+
+	var svrResponseBuf bytes.Buffer
+	err := internal.WriteDelimitedMessage(&svrResponseBuf, &conformancev1.ServerCompatResponse{
+		Host: "127.0.0.1",
+		Port: 12345,
+	})
+	require.NoError(t, err)
+	svrResponseData := svrResponseBuf.Bytes()
+
+	testCaseData := []*conformancev1.TestCase{
+		{
+			Request:          &conformancev1.ClientCompatRequest{TestName: "known-to-flake/eventually-passes"},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		},
+		{
+			Request:          &conformancev1.ClientCompatRequest{TestName: "known-to-flake/always-fails"},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		},
+		{
+			Request:          &conformancev1.ClientCompatRequest{TestName: "foo/fails"},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		},
+		{
+			Request:          &conformancev1.ClientCompatRequest{TestName: "known-to-flake/server-feedback"},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		},
+	}
+
+	// Each retry uses a new server process. Only the first one reports
+	// feedback about a test case.
+	var serverStarts int
+	startServer := func(ctx context.Context, pipeStderr bool) (*process, error) {
+		serverStarts++
+		stderr := ""
+		if serverStarts == 1 {
+			stderr = `{"testName": "known-to-flake/server-feedback", "message": "something awry"}` + "\n"
+		}
+		return newFakeProcess(io.Discard, bytes.NewReader(svrResponseData), strings.NewReader(stderr))(ctx, pipeStderr)
+	}
+
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.flakyRetries = 3
+	client := &flakyClient{
+		failuresRemaining: map[string]int{
+			"known-to-flake/eventually-passes": 2,
+			"known-to-flake/always-fails":      10,
+			"foo/fails":                        10,
+		},
+		attempts: map[string]int{},
+	}
+	runTestCasesForServer(
+		context.Background(),
+		true,
+		true,
+		serverInstance{},
+		"test server",
+		testCaseData,
+		nil,
+		startServer,
+		internal.NewCodec(false),
+		discardPrinter{},
+		discardPrinter{},
+		results,
+		client,
+		nil,
+		false,
+	)
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	assert.Equal(t, map[string]int{
+		"known-to-flake/eventually-passes": 3,
+		"known-to-flake/always-fails":      4,
+		"foo/fails":                        1,
+		"known-to-flake/server-feedback":   2,
+	}, client.attempts)
+	assert.Equal(t, 4, serverStarts)
+
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	assert.NoError(t, results.outcomes["known-to-flake/eventually-passes"].actualFailure)
+	assert.Error(t, results.outcomes["known-to-flake/always-fails"].actualFailure)
+	assert.Error(t, results.outcomes["foo/fails"].actualFailure)
+	assert.NoError(t, results.outcomes["known-to-flake/server-feedback"].actualFailure)
+	assert.Empty(t, results.serverSideband["known-to-flake/server-feedback"])
+}
+
+func TestRunTestCasesForServer_MaxFailures(t *testing.T) {
+	t.Parallel()
+
+	var svrResponseBuf bytes.Buffer
+	err := internal.WriteDelimitedMessage(&svrResponseBuf, &conformancev1.ServerCompatResponse{
+		Host: "127.0.0.1",
+		Port: 12345,
+	})
+	require.NoError(t, err)
+	svrResponseData := svrResponseBuf.Bytes()
+
+	names := []string{"foo/1", "known-to-fail/2", "foo/3", "foo/4", "foo/5"}
+	testCaseData := make([]*conformancev1.TestCase, len(names))
+	client := &fakeClient{responses: map[string]*conformancev1.ClientCompatResponse{}}
+	for i, name := range names {
+		testCaseData[i] = &conformancev1.TestCase{
+			Request:          &conformancev1.ClientCompatRequest{TestName: name},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		}
+		client.responses[name] = &conformancev1.ClientCompatResponse{
+			TestName: name,
+			Result: &conformancev1.ClientCompatResponse_Error{
+				Error: &conformancev1.ClientErrorResult{Message: "ruh roh"},
+			},
+		}
+	}
+
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.maxFailures = 2
+	var stopped int
+	results.stopDispatch = func() { stopped++ }
+	runTestCasesForServer(
+		context.Background(),
+		true,
+		false,
+		serverInstance{},
+		"test server",
+		testCaseData,
+		nil,
+		newFakeProcess(io.Discard, bytes.NewReader(svrResponseData), nil),
+		internal.NewCodec(false),
+		discardPrinter{},
+		discardPrinter{},
+		results,
+		client,
+		nil,
+		false,
+	)
+
+	// Expected failures don't count towards the maximum.
+	assert.Len(t, client.actualRequests, 3)
+	assert.Equal(t, 1, stopped)
+	assert.True(t, results.dispatchStopped())
+	results.mu.Lock()
+	assert.Len(t, results.outcomes, 3)
+	assert.Equal(t, map[string]struct{}{"foo/4": {}, "foo/5": {}}, results.skipped)
+	results.mu.Unlock()
+
+	logger := &internal.SimplePrinter{}
+	require.False(t, results.report(logger))
+	assert.Contains(t, strings.Join(logger.Messages, ""),
+		"2 failed\n(Another 1 failed as expected due to being known failures/flakes.)\n(Another 2 were skipped after reaching the maximum of 2 failure(s).)\n")
+}
+
+func TestRunTestCasesForServer_ReferenceClientFeedback(t *testing.T) {
+	t.Parallel()
+
+	var svrResponseBuf bytes.Buffer
+	err := internal.WriteDelimitedMessage(&svrResponseBuf, &conformancev1.ServerCompatResponse{
+		Host: "127.0.0.1",
+		Port: 12345,
+	})
+	require.NoError(t, err)
+	svrResponseData := svrResponseBuf.Bytes()
+
+	feedback := map[string]*conformancev1.ClientResponseResult{
+		"foo/none":            {},
+		"foo/required":        {Feedback: []string{"something awry"}},
+		"foo/recommended":     {RecommendationFeedback: []string{"something awkward"}},
+		"foo/both":            {Feedback: []string{"something awry"}, RecommendationFeedback: []string{"something awkward"}},
+		"foo/recommended/two": {RecommendationFeedback: []string{"something awkward", "something else awkward"}},
+	}
+	var testCaseData []*conformancev1.TestCase
+	client := &fakeClient{responses: map[string]*conformancev1.ClientCompatResponse{}}
+	for _, name := range sortedKeys(feedback) {
+		testCaseData = append(testCaseData, &conformancev1.TestCase{
+			Request:          &conformancev1.ClientCompatRequest{TestName: name},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		})
+		client.responses[name] = &conformancev1.ClientCompatResponse{
+			TestName: name,
+			Result:   &conformancev1.ClientCompatResponse_Response{Response: feedback[name]},
+		}
+	}
+
+	results := newResults(&testTrie{}, &testTrie{}, nil)
+	runTestCasesForServer(
+		context.Background(),
+		true,
+		false,
+		serverInstance{},
+		"test server",
+		testCaseData,
+		nil,
+		newFakeProcess(io.Discard, bytes.NewReader(svrResponseData), nil),
+		internal.NewCodec(false),
+		discardPrinter{},
+		discardPrinter{},
+		results,
+		client,
+		nil,
+		false,
+	)
+
+	results.mu.Lock()
+	results.processSidebandInfoLocked()
+	statuses := map[string]outcomeStatus{}
+	for name, outcome := range results.outcomes {
+		statuses[name] = outcome.status()
+	}
+	results.mu.Unlock()
+	assert.Equal(t, map[string]outcomeStatus{
+		"foo/none":            statusPassed,
+		"foo/required":        statusFailed,
+		"foo/recommended":     statusWarning,
+		"foo/both":            statusFailed,
+		"foo/recommended/two": statusWarning,
+	}, statuses)
+}
+
+func TestRunTestCasesForServer_ReferenceServerRecommendations(t *testing.T) {
+	t.Parallel()
+
+	svrInstance := serverInstance{
+		protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
+		httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+	}
+	newTestCase := func(name string) *conformancev1.TestCase {
+		return &conformancev1.TestCase{
+			Request: &conformancev1.ClientCompatRequest{
+				TestName:    name,
+				HttpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+				Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
+				Codec:       conformancev1.Codec_CODEC_PROTO,
+				Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+				Service:     proto.String(conformancev1connect.ConformanceServiceName),
+				Method:      proto.String("Unary"),
+				StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+			},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		}
+	}
+	testCaseData := []*conformancev1.TestCase{newTestCase("foo/with-te"), newTestCase("foo/without-te")}
+
+	for _, strict := range []bool{false, true} {
+		results := newResults(&testTrie{}, &testTrie{}, nil)
+		results.strict = strict
+		// The client omits the "te: trailers" header from one request, which
+		// the reference server only recommends.
+		client := &rawHTTPClient{omitTE: map[string]bool{"foo/without-te": true}}
+		runTestCasesForServer(
+			context.Background(),
+			false,
+			true,
+			svrInstance,
+			"reference server",
+			testCaseData,
+			nil,
+			runInProcess([]string{"reference-server", "-bind", "127.0.0.1"},
+				func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+					return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, nil)
+				}),
+			internal.NewCodec(false),
+			discardPrinter{},
+			discardPrinter{},
+			results,
+			client,
+			nil,
+			false,
+		)
+
+		results.mu.Lock()
+		results.processSidebandInfoLocked()
+		withTE, withoutTE := results.outcomes["foo/with-te"], results.outcomes["foo/without-te"]
+		results.mu.Unlock()
+		assert.Equal(t, statusPassed, withTE.status(), "%v", withTE.actualFailure)
+		if strict {
+			assert.Equal(t, statusFailed, withoutTE.status())
+		} else {
+			assert.Equal(t, statusWarning, withoutTE.status())
+		}
+		require.Error(t, withoutTE.actualFailure)
+		assert.Contains(t, withoutTE.actualFailure.Error(), "gRPC protocol client should use 'te: trailers' header")
+	}
+}
+
+func TestRunTestCasesForServer_JSON(t *testing.T) {
+	t.Parallel()
+
+	svrResponseData := []byte(`{"host":"127.0.0.1","port":12345}` + "\n")
+	testCaseData := []*conformancev1.TestCase{
+		{
+			Request:          &conformancev1.ClientCompatRequest{TestName: "foo/1"},
+			ExpectedResponse: &conformancev1.ClientResponseResult{},
+		},
+	}
+	client := &fakeClient{responses: map[string]*conformancev1.ClientCompatResponse{
+		"foo/1": {
+			TestName: "foo/1",
+			Result: &conformancev1.ClientCompatResponse_Response{
+				Response: &conformancev1.ClientResponseResult{},
+			},
+		},
+	}}
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	var svrRequest bytes.Buffer
+	runTestCasesForServer(
+		context.Background(),
+		true,
+		false,
+		serverInstance{
+			protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+			httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_2,
+		},
+		"test server",
+		testCaseData,
+		nil,
+		newFakeProcess(&svrRequest, bytes.NewReader(svrResponseData), nil),
+		newStdioCodec(conformancev1.StdioFormat_STDIO_FORMAT_JSON),
+		discardPrinter{},
+		discardPrinter{},
+		results,
+		client,
+		nil,
+		false,
+	)
+
+	// The request is written as a single line of JSON.
+	line, err := svrRequest.ReadString('\n')
+	require.NoError(t, err)
+	assert.Zero(t, svrRequest.Len())
+	var req conformancev1.ServerCompatRequest
+	require.NoError(t, protojson.Unmarshal([]byte(line), &req))
+	assert.Empty(t, cmp.Diff(&conformancev1.ServerCompatRequest{
+		Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+		HttpVersion:         conformancev1.HTTPVersion_HTTP_VERSION_2,
+		MessageReceiveLimit: serverReceiveLimit,
+	}, &req, protocmp.Transform()))
+
+	require.Len(t, client.actualRequests, 1)
+	assert.Equal(t, "127.0.0.1", client.actualRequests[0].Host)
+	assert.Equal(t, uint32(12345), client.actualRequests[0].Port)
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	require.Contains(t, results.outcomes, "foo/1")
+	assert.NoError(t, results.outcomes["foo/1"].actualFailure)
+}
+
 // fakeProcess is a process starter that represents a fictitious process
 // that is runs until the stop method is called.
 type fakeProcess struct {
@@ -492,6 +848,58 @@ func (f *fakeClient) isRunning() bool {
 func (f *fakeClient) stop() {
 }
 
+// rawHTTPClient is a client runner that sends a unary gRPC request, with an
+// empty message, to the server for each test case. It reports an empty
+// response, regardless of the server's response.
+type rawHTTPClient struct {
+	omitTE map[string]bool
+}
+
+func (c *rawHTTPClient) sendRequest(req *conformancev1.ClientCompatRequest, whenDone func(string, *conformancev1.ClientCompatResponse, error)) error {
+	url := fmt.Sprintf("http://%s:%d/%s/%s", req.Host, req.Port, req.GetService(), req.GetMethod())
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader([]byte{0, 0, 0, 0, 0}))
+	if err != nil {
+		return err
+	}
+	for _, hdr := range req.RequestHeaders {
+		for _, val := range hdr.Value {
+			httpReq.Header.Add(hdr.Name, val)
+		}
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	if !c.omitTE[req.TestName] {
+		httpReq.Header.Set("Te", "trailers")
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		whenDone(req.TestName, nil, err)
+		return nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	whenDone(req.TestName, &conformancev1.ClientCompatResponse{
+		TestName: req.TestName,
+		Result: &conformancev1.ClientCompatResponse_Response{
+			Response: &conformancev1.ClientResponseResult{},
+		},
+	}, nil)
+	return nil
+}
+
+func (c *rawHTTPClient) closeSend() {
+}
+
+func (c *rawHTTPClient) waitForResponses() error {
+	return nil
+}
+
+func (c *rawHTTPClient) isRunning() bool {
+	return false
+}
+
+func (c *rawHTTPClient) stop() {
+}
+
 type discardPrinter struct{}
 
 func (d discardPrinter) Printf(_ string, _ ...any) {
@@ -499,3 +907,45 @@ func (d discardPrinter) Printf(_ string, _ ...any) {
 
 func (d discardPrinter) PrefixPrintf(_, _ string, _ ...any) {
 }
+
+// flakyClient is a client runner that reports errors for the
+// first so-many attempts for a test case. It invokes callbacks
+// asynchronously, like a real client runner.
+type flakyClient struct {
+	mu                sync.Mutex
+	failuresRemaining map[string]int
+	attempts          map[string]int
+}
+
+func (f *flakyClient) sendRequest(req *conformancev1.ClientCompatRequest, whenDone func(string, *conformancev1.ClientCompatResponse, error)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts[req.TestName]++
+	resp := &conformancev1.ClientCompatResponse{TestName: req.TestName}
+	if f.failuresRemaining[req.TestName] > 0 {
+		f.failuresRemaining[req.TestName]--
+		resp.Result = &conformancev1.ClientCompatResponse_Error{
+			Error: &conformancev1.ClientErrorResult{Message: "flaked"},
+		}
+	} else {
+		resp.Result = &conformancev1.ClientCompatResponse_Response{
+			Response: &conformancev1.ClientResponseResult{},
+		}
+	}
+	go whenDone(req.TestName, resp, nil)
+	return nil
+}
+
+func (f *flakyClient) closeSend() {
+}
+
+func (f *flakyClient) waitForResponses() error {
+	return nil
+}
+
+func (f *flakyClient) isRunning() bool {
+	return true
+}
+
+func (f *flakyClient) stop() {
+}
@@ -0,0 +1,183 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// testShard is the subset of test cases that are run by a single
+// worker when the test cases are split across multiple workers.
+type testShard struct {
+	// names of test cases in this shard, without any gRPC impl markers
+	names map[string]struct{}
+	// number of server instances with test cases in this shard
+	numServers int
+}
+
+// shardUnit is a set of test cases for a single server instance that
+// are assigned to a shard together.
+type shardUnit struct {
+	// index of the unit's server instance
+	server int
+	// used to break ties, for deterministic ordering
+	order  int
+	cases  []string
+	weight int
+}
+
+// newShard computes the test cases that belong to the shard with the given
+// index, out of the given number of shards. The given permutations, after
+// applying the given filter, are split deterministically across shards.
+// Test cases are kept together by server instance, so that each shard starts
+// as few server processes as possible, but the shards are also balanced so
+// that each has roughly the same number of test cases. This returns nil if
+// count is less than two, indicating that no sharding is done. A count of
+// zero is the same as one. An error is returned if index is out of range.
+func newShard(
+	testCaseLib *testCaseLibrary,
+	permutations []*conformancev1.TestCase,
+	filter *testCaseFilter,
+	index, count uint,
+) (*testShard, error) {
+	if count == 0 {
+		count = 1
+	}
+	if index >= count {
+		return nil, fmt.Errorf("invalid shard index %d: must be less than shard count %d", index, count)
+	}
+	if count < 2 {
+		return nil, nil //nolint:nilnil
+	}
+	// A test case can have multiple permutations (with gRPC impls), which
+	// are all run against the same server instance. So we weigh each test
+	// case by the number of its permutations that will actually be run.
+	weights := map[string]int{}
+	for _, testCase := range permutations {
+		if filter.accept(testCase) {
+			weights[baseTestName(testCase.Request.TestName)]++
+		}
+	}
+	units := make([]*shardUnit, 0, len(testCaseLib.casesByServer))
+	for _, svrInstance := range serverInstancesSlice(testCaseLib, true) {
+		unit := &shardUnit{server: len(units), order: len(units)}
+		for _, testCase := range testCaseLib.casesByServer[svrInstance] {
+			name := testCase.Request.TestName
+			if weight := weights[name]; weight > 0 {
+				unit.cases = append(unit.cases, name)
+				unit.weight += weight
+			}
+		}
+		if len(unit.cases) > 0 {
+			sort.Strings(unit.cases)
+			units = append(units, unit)
+		}
+	}
+	// If any server instance has more test cases than a shard should,
+	// split it up, so it can be spread across multiple shards.
+	var total int
+	for _, unit := range units {
+		total += unit.weight
+	}
+	maxWeight := (total + int(count) - 1) / int(count)
+	for {
+		var largest *shardUnit
+		for _, unit := range units {
+			if len(unit.cases) > 1 && unit.weight > maxWeight && (largest == nil || unit.weight > largest.weight) {
+				largest = unit
+			}
+		}
+		if largest == nil {
+			break
+		}
+		half := len(largest.cases) / 2
+		other := &shardUnit{server: largest.server, order: len(units), cases: largest.cases[half:]}
+		largest.cases = largest.cases[:half]
+		largest.weight = 0
+		for _, name := range largest.cases {
+			largest.weight += weights[name]
+		}
+		for _, name := range other.cases {
+			other.weight += weights[name]
+		}
+		units = append(units, other)
+	}
+	// Greedily assign the heaviest units first, each to the shard with
+	// the lowest total weight so far.
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].weight != units[j].weight {
+			return units[i].weight > units[j].weight
+		}
+		return units[i].order < units[j].order
+	})
+	loads := make([]int, count)
+	shard := &testShard{names: map[string]struct{}{}}
+	servers := map[int]struct{}{}
+	for _, unit := range units {
+		var target int
+		for i := range loads {
+			if loads[i] < loads[target] {
+				target = i
+			}
+		}
+		loads[target] += unit.weight
+		if target != int(index) {
+			continue
+		}
+		servers[unit.server] = struct{}{}
+		for _, name := range unit.cases {
+			shard.names[name] = struct{}{}
+		}
+	}
+	shard.numServers = len(servers)
+	return shard, nil
+}
+
+// apply returns the subset of the given test cases that are in the shard.
+// If s is nil, all test cases are returned.
+func (s *testShard) apply(testCases []*conformancev1.TestCase) []*conformancev1.TestCase {
+	if s == nil {
+		return testCases
+	}
+	results := make([]*conformancev1.TestCase, 0, len(testCases))
+	for _, testCase := range testCases {
+		if _, ok := s.names[baseTestName(testCase.Request.TestName)]; ok {
+			results = append(results, testCase)
+		}
+	}
+	return results
+}
+
+// baseTestName returns the given test case name with any gRPC impl
+// markers removed.
+func baseTestName(name string) string {
+	if !strings.Contains(name, "(grpc ") {
+		return name
+	}
+	components := strings.Split(name, "/")
+	filtered := components[:0]
+	for _, component := range components {
+		switch component {
+		case grpcImplMarker, grpcClientImplMarker, grpcServerImplMarker:
+		default:
+			filtered = append(filtered, component)
+		}
+	}
+	return strings.Join(filtered, "/")
+}
@@ -0,0 +1,145 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShard(t *testing.T) {
+	t.Parallel()
+	lib := &testCaseLibrary{
+		testCases:     map[string]*conformancev1.TestCase{},
+		casesByServer: map[serverInstance][]*conformancev1.TestCase{},
+	}
+	var permutations []*conformancev1.TestCase
+	numCases := []int{40, 30, 20, 10, 10, 5}
+	for i, num := range numCases {
+		svrInstance := serverInstance{
+			protocol:    conformancev1.Protocol(i%3 + 1),
+			httpVersion: conformancev1.HTTPVersion(i/3 + 1),
+		}
+		for j := 0; j < num; j++ {
+			testCase := &conformancev1.TestCase{
+				Request: &conformancev1.ClientCompatRequest{TestName: fmt.Sprintf("Suite/%d/case-%d", i, j)},
+			}
+			lib.testCases[testCase.Request.TestName] = testCase
+			lib.casesByServer[svrInstance] = append(lib.casesByServer[svrInstance], testCase)
+			permutations = append(permutations, testCase)
+		}
+	}
+
+	shard, err := newShard(lib, permutations, nil, 0, 1)
+	require.NoError(t, err)
+	require.Nil(t, shard)
+	shard, err = newShard(lib, permutations, nil, 0, 0)
+	require.NoError(t, err)
+	require.Nil(t, shard)
+	_, err = newShard(lib, permutations, nil, 1, 1)
+	require.EqualError(t, err, "invalid shard index 1: must be less than shard count 1")
+	_, err = newShard(lib, permutations, nil, 3, 3)
+	require.EqualError(t, err, "invalid shard index 3: must be less than shard count 3")
+
+	testCases := []struct {
+		name               string
+		count              uint
+		filter             *testCaseFilter
+		expectTotal        int
+		expectMaxServers   int
+		expectMaxImbalance int
+	}{
+		{
+			name:               "two shards",
+			count:              2,
+			expectTotal:        115,
+			expectMaxServers:   5,
+			expectMaxImbalance: 5,
+		},
+		{
+			name:               "three shards",
+			count:              3,
+			expectTotal:        115,
+			expectMaxServers:   3,
+			expectMaxImbalance: 5,
+		},
+		{
+			name:               "more shards than servers",
+			count:              10,
+			expectTotal:        115,
+			expectMaxServers:   2,
+			expectMaxImbalance: 10,
+		},
+		{
+			name:               "filtered",
+			count:              3,
+			filter:             newFilter(parsePatterns([]string{"Suite/0/**", "Suite/1/**"}), nil, nil, nil),
+			expectTotal:        70,
+			expectMaxServers:   2,
+			expectMaxImbalance: 15,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			seen := map[string]int{}
+			minSize, maxSize := -1, 0
+			for i := uint(0); i < testCase.count; i++ {
+				shard, err := newShard(lib, permutations, testCase.filter, i, testCase.count)
+				require.NoError(t, err)
+				require.NotNil(t, shard)
+				// deterministic
+				again, err := newShard(lib, permutations, testCase.filter, i, testCase.count)
+				require.NoError(t, err)
+				assert.Equal(t, shard, again)
+				assert.LessOrEqual(t, shard.numServers, testCase.expectMaxServers)
+				for name := range shard.names {
+					seen[name]++
+				}
+				size := len(shard.names)
+				if minSize == -1 || size < minSize {
+					minSize = size
+				}
+				if size > maxSize {
+					maxSize = size
+				}
+			}
+			// every test case is in exactly one shard
+			assert.Len(t, seen, testCase.expectTotal)
+			for name, count := range seen {
+				assert.Equal(t, 1, count, name)
+			}
+			assert.LessOrEqual(t, maxSize-minSize, testCase.expectMaxImbalance)
+		})
+	}
+}
+
+func TestShard_Apply(t *testing.T) {
+	t.Parallel()
+	shard := &testShard{names: map[string]struct{}{"Suite/foo/bar": {}}}
+	testCases := []*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{TestName: "Suite/foo/bar"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "Suite/foo/(grpc client impl)/bar"}},
+		{Request: &conformancev1.ClientCompatRequest{TestName: "Suite/foo/baz"}},
+	}
+	assert.Equal(t, testCases[:2], shard.apply(testCases))
+	var nilShard *testShard
+	assert.Equal(t, testCases, nilShard.apply(testCases))
+}
@@ -0,0 +1,116 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+)
+
+const stateFileHeader = "# Test cases that failed the last time they were run. This file is\n" +
+	"# updated after every run and is used by the --rerun-failed flag.\n"
+
+// readFailedState reads the names of the test cases that failed in the
+// previous run from the named state file. If the file does not exist,
+// it returns no names and no error.
+func readFailedState(fileName string) ([]string, error) {
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, internal.EnsureFileName(err, fileName)
+	}
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, nil
+}
+
+// writeFailedState writes the names of all test cases that failed in this
+// run to the named state file. The given names, which are those that failed
+// in previous runs, are also included if those test cases did not run this
+// time, such as when they were filtered out. Test cases that are known to
+// fail, that failed as expected, or that only had warnings are not included.
+func (r *testResults) writeFailedState(fileName string, previouslyFailed []string) error {
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	r.processSidebandInfoLocked()
+	failed := map[string]struct{}{}
+	for _, name := range previouslyFailed {
+		if _, ran := r.outcomes[name]; !ran {
+			failed[name] = struct{}{}
+		}
+	}
+	for name, outcome := range r.outcomes {
+		if outcome.status().isFailure() {
+			failed[name] = struct{}{}
+		}
+	}
+	r.mu.Unlock()
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return writeFile(fileName, func(w io.Writer) error {
+		bufWriter := bufio.NewWriter(w)
+		_, _ = bufWriter.WriteString(stateFileHeader)
+		for _, name := range names {
+			_, _ = bufWriter.WriteString(name)
+			_ = bufWriter.WriteByte('\n')
+		}
+		return bufWriter.Flush()
+	})
+}
+
+// reportPreviouslyFailed prints how many of the given test cases, which
+// failed in the previous run, now pass. Test cases that were not run
+// this time are not counted.
+func (r *testResults) reportPreviouslyFailed(printer internal.Printer, previouslyFailed []string) {
+	r.traceWaitGroup.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processSidebandInfoLocked()
+	var ran, passed int
+	for _, name := range previouslyFailed {
+		outcome, ok := r.outcomes[name]
+		if !ok {
+			continue
+		}
+		ran++
+		if outcome.status() == statusPassed {
+			passed++
+		}
+	}
+	if ran == 0 {
+		return
+	}
+	printer.Printf("%d of %d previously failing test case(s) now pass.", passed, ran)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_FailedState(t *testing.T) {
+	t.Parallel()
+	fileName := filepath.Join(t.TempDir(), "state")
+
+	// A missing state file means nothing failed previously.
+	names, err := readFailedState(fileName)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("ruh roh"))
+	results.setOutcome("foo/bar/3", true, errors.New("ruh roh"))
+	results.setOutcome("known-to-fail/1", false, errors.New("ruh roh"))
+	results.setOutcome("known-to-fail/2", false, nil)
+	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
+	require.NoError(t, results.writeFailedState(fileName, names))
+
+	names, err = readFailedState(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo/bar/2", "foo/bar/3", "known-to-fail/2"}, names)
+
+	// Rerun, where some of the previous failures now pass.
+	results = newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/2", false, nil)
+	results.setOutcome("foo/bar/3", false, errors.New("ruh roh"))
+	logger := &internal.SimplePrinter{}
+	results.reportPreviouslyFailed(logger, names)
+	assert.Equal(t, []string{"1 of 2 previously failing test case(s) now pass.\n"}, logger.Messages)
+
+	// Test cases that didn't run, like known-to-fail/2, keep their entries.
+	require.NoError(t, results.writeFailedState(fileName, names))
+	names, err = readFailedState(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo/bar/3", "known-to-fail/2"}, names)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// tagPatternPrefix is the prefix of a known-failing or known-flaky pattern
+// that names a tag, instead of being a pattern of test case names. All test
+// cases with the tag are matched.
+const tagPatternPrefix = "tag:"
+
+// splitTagPatterns separates the given known-failing or known-flaky patterns
+// into patterns of test case names and tags.
+func splitTagPatterns(patterns []string) (names, tags []string) {
+	for _, pattern := range patterns {
+		if tag, ok := strings.CutPrefix(pattern, tagPatternPrefix); ok {
+			tags = append(tags, tag)
+			continue
+		}
+		names = append(names, pattern)
+	}
+	return names, tags
+}
+
+// tryMatchTags returns an error if any of the given tags is not found on
+// any of the given test cases. This prevents typos in tags from going
+// unnoticed. It returns the number of test cases that have any of the tags.
+func tryMatchTags(what string, tags []string, testCases []*conformancev1.TestCase) (int, error) {
+	var matchCount int
+	matched := make(map[string]struct{}, len(tags))
+	for _, testCase := range testCases {
+		var anyMatched bool
+		for _, tag := range tags {
+			if contains(testCase.Tags, tag) {
+				matched[tag] = struct{}{}
+				anyMatched = true
+			}
+		}
+		if anyMatched {
+			matchCount++
+		}
+	}
+	var unmatched []string
+	for _, tag := range tags {
+		if _, ok := matched[tag]; !ok {
+			unmatched = append(unmatched, tag)
+		}
+	}
+	if len(unmatched) == 0 {
+		return matchCount, nil
+	}
+	sort.Strings(unmatched)
+	return matchCount, fmt.Errorf("%s: unmatched and possibly invalid tags:\n%v", what, strings.Join(unmatched, "\n"))
+}
+
+// validateTags checks that the given tags are valid: each must be non-empty
+// and contain no whitespace or commas.
+func validateTags(tags []string) error {
+	var errs []error
+	for _, tag := range tags {
+		switch {
+		case tag == "":
+			errs = append(errs, errors.New("tag must not be empty"))
+		case strings.ContainsAny(tag, ", \t\r\n"):
+			errs = append(errs, fmt.Errorf("tag %q must not contain whitespace or commas", tag))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mergeTags returns the union of the given suite and test case tags, with
+// the suite's tags first and without duplicates.
+func mergeTags(suiteTags, caseTags []string) []string {
+	if len(suiteTags) == 0 {
+		return caseTags
+	}
+	merged := make([]string, 0, len(suiteTags)+len(caseTags))
+	for _, tags := range [][]string{suiteTags, caseTags} {
+		for _, tag := range tags {
+			if !contains(merged, tag) {
+				merged = append(merged, tag)
+			}
+		}
+	}
+	return merged
+}
+
+// hasAnyTag returns true if the given tags include any of the given targets.
+func hasAnyTag(tags, targets []string) bool {
+	for _, target := range targets {
+		if contains(tags, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTagPatterns(t *testing.T) {
+	t.Parallel()
+	names, tags := splitTagPatterns([]string{"foo/**", "tag:trailers", "bar/baz", "tag:cancellation"})
+	assert.Equal(t, []string{"foo/**", "bar/baz"}, names)
+	assert.Equal(t, []string{"trailers", "cancellation"}, tags)
+}
+
+func TestMergeTags(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, mergeTags(nil, nil))
+	assert.Equal(t, []string{"a"}, mergeTags(nil, []string{"a"}))
+	assert.Equal(t, []string{"a", "b", "c"}, mergeTags([]string{"a", "b"}, []string{"b", "c"}))
+}
+
+func TestValidateTags(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateTags([]string{"trailers", "error-details", "http_2"}))
+	require.EqualError(t, validateTags([]string{"", "a b", "a,b"}),
+		"tag must not be empty\n"+`tag "a b" must not contain whitespace or commas`+"\n"+`tag "a,b" must not contain whitespace or commas`)
+}
+
+func TestTryMatchTags(t *testing.T) {
+	t.Parallel()
+	testCases := []*conformancev1.TestCase{
+		{Tags: []string{"a", "b"}},
+		{Tags: []string{"b"}},
+		{},
+	}
+	matched, err := tryMatchTags("run tags", []string{"a", "b"}, testCases)
+	require.NoError(t, err)
+	assert.Equal(t, 2, matched)
+	_, err = tryMatchTags("run tags", []string{"z", "a", "y"}, testCases)
+	require.EqualError(t, err, "run tags: unmatched and possibly invalid tags:\ny\nz")
+}
+
+func TestTestCaseLibrary_Tags(t *testing.T) {
+	t.Parallel()
+	testSuites, err := parseTestSuites(map[string][]byte{"tags.yaml": []byte(`name: Tags
+tags: [suite, shared]
+testCases:
+- request:
+    testName: untagged
+    streamType: STREAM_TYPE_UNARY
+- request:
+    testName: tagged
+    streamType: STREAM_TYPE_UNARY
+  tags: [shared, extra]
+`)})
+	require.NoError(t, err)
+	lib, err := newTestCaseLibrary(testSuites, []configCase{{
+		Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
+		Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+		Codec:       conformancev1.Codec_CODEC_PROTO,
+		Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+		StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+	}}, conformancev1.TestSuite_TEST_MODE_CLIENT)
+	require.NoError(t, err)
+	tags := map[string][]string{}
+	for _, testCase := range lib.testCases {
+		tags[lib.testCaseNames[testCase.Request.TestName]] = testCase.Tags
+	}
+	assert.Equal(t, map[string][]string{
+		"untagged": {"suite", "shared"},
+		"tagged":   {"suite", "shared", "extra"},
+	}, tags)
+
+	_, err = parseTestSuites(map[string][]byte{"bad.yaml": []byte(`name: Bad
+tags: ["bad tag"]
+testCases:
+- request:
+    testName: foo
+    streamType: STREAM_TYPE_UNARY
+`)})
+	require.EqualError(t, err, `bad.yaml: suite has invalid tags: tag "bad tag" must not contain whitespace or commas`)
+}
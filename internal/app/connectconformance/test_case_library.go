@@ -19,14 +19,13 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"regexp"
 	"sort"
 	"strings"
 
-	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
 	"connectrpc.com/connect"
-	"github.com/bufbuild/protoyaml-go"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -82,16 +81,25 @@ func newTestCaseLibrary(
 	configCases []configCase,
 	mode conformancev1.TestSuite_TestMode,
 ) (*testCaseLibrary, error) {
+	// De-dup and sort the config cases, so that test cases are expanded (and
+	// any errors reported) in a deterministic order.
 	configCaseSet := make(map[configCase]struct{}, len(configCases))
+	uniqueConfigCases := make([]configCase, 0, len(configCases))
 	for _, c := range configCases {
+		if _, exists := configCaseSet[c]; exists {
+			continue
+		}
 		configCaseSet[c] = struct{}{}
+		uniqueConfigCases = append(uniqueConfigCases, c)
 	}
+	sortConfigCases(uniqueConfigCases)
 	lib := &testCaseLibrary{
 		testCases:     map[string]*conformancev1.TestCase{},
 		testCaseNames: map[string]string{},
 	}
 	suitesIndex := make(map[string]string, len(allSuites))
-	for file, suite := range allSuites {
+	for _, file := range sortedKeys(allSuites) {
+		suite := allSuites[file]
 		if suite.Name == "" {
 			return nil, fmt.Errorf("%s defines a suite with no name", file)
 		}
@@ -105,7 +113,7 @@ func newTestCaseLibrary(
 		if suite.Mode != conformancev1.TestSuite_TEST_MODE_UNSPECIFIED && suite.Mode != mode {
 			continue // skip it
 		}
-		if err := lib.expandSuite(suite, configCaseSet); err != nil {
+		if err := lib.expandSuite(suite, uniqueConfigCases); err != nil {
 			return nil, err
 		}
 	}
@@ -120,7 +128,7 @@ func newTestCaseLibrary(
 	return lib, nil
 }
 
-func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCases map[configCase]struct{}) error {
+func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCases []configCase) error {
 	if suite.ReliesOnTlsClientCerts && !suite.ReliesOnTls {
 		return fmt.Errorf("suite %q is misconfigured: it relies on TLS client certs but not TLS", suite.Name)
 	}
@@ -133,60 +141,69 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 	if suite.ConnectVersionMode == conformancev1.TestSuite_CONNECT_VERSION_MODE_REQUIRE && !only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
 		return fmt.Errorf("suite %q is misconfigured: it requires Connect Version headers, but has unexpected relevant protocols: %v", suite.Name, suite.RelevantProtocols)
 	}
-	protocols := suite.RelevantProtocols
-	if len(protocols) == 0 {
-		protocols = allProtocols
-	}
-	for _, protocol := range protocols {
-		httpVersions := suite.RelevantHttpVersions
-		if len(httpVersions) == 0 {
-			httpVersions = allHTTPVersions
+	for _, cfgCase := range configCases {
+		if len(suiteExclusionReasons(suite, cfgCase)) > 0 {
+			continue
 		}
-		for _, httpVersion := range httpVersions {
-			tlsCases := []bool{true, false}
-			if suite.ReliesOnTls {
-				tlsCases = []bool{true} // can't run these cases w/out TLS
-			}
-			for _, tlsCase := range tlsCases {
-				codecs := suite.RelevantCodecs
-				if len(codecs) == 0 {
-					codecs = allCodecs
-				}
-				for _, codec := range codecs {
-					compressions := suite.RelevantCompressions
-					if len(compressions) == 0 {
-						compressions = allCompressions
-					}
-					for _, compression := range compressions {
-						for _, streamType := range allStreamTypes {
-							cfgCase := configCase{
-								Version:                httpVersion,
-								Protocol:               protocol,
-								Codec:                  codec,
-								Compression:            compression,
-								StreamType:             streamType,
-								UseTLS:                 tlsCase,
-								UseTLSClientCerts:      suite.ReliesOnTlsClientCerts,
-								UseConnectGET:          suite.ReliesOnConnectGet,
-								ConnectVersionMode:     suite.ConnectVersionMode,
-								UseMessageReceiveLimit: suite.ReliesOnMessageReceiveLimit,
-							}
-							if _, ok := configCases[cfgCase]; ok {
-								namePrefix := generateTestCasePrefix(suite, cfgCase)
-								if err := lib.expandCases(cfgCase, namePrefix, suite.TestCases); err != nil {
-									return fmt.Errorf("failed to expand test cases for suite %s: %w", suite.Name, err)
-								}
-							}
-						}
-					}
-				}
-			}
+		namePrefix := generateTestCasePrefix(suite, cfgCase)
+		if err := lib.expandCases(cfgCase, namePrefix, suite.Tags, suite.TestCases); err != nil {
+			return fmt.Errorf("failed to expand test cases for suite %s: %w", suite.Name, err)
 		}
 	}
 	return nil
 }
 
-func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string, testCases []*conformancev1.TestCase) error {
+// suiteExclusionReasons returns the reasons why the given config case is not
+// used for the given suite. If the returned slice is empty, the suite's test
+// cases whose stream type matches the config case are run with it. This is
+// also used to explain exclusions, so each reason should be a short phrase
+// that describes the suite constraint that isn't met.
+func suiteExclusionReasons(suite *conformancev1.TestSuite, cfgCase configCase) []string {
+	var reasons []string
+	if len(suite.RelevantHttpVersions) > 0 && !contains(suite.RelevantHttpVersions, cfgCase.Version) {
+		reasons = append(reasons, fmt.Sprintf("%s not in suite's relevant_http_versions", cfgCase.Version))
+	}
+	if len(suite.RelevantProtocols) > 0 && !contains(suite.RelevantProtocols, cfgCase.Protocol) {
+		reasons = append(reasons, fmt.Sprintf("%s not in suite's relevant_protocols", cfgCase.Protocol))
+	}
+	if len(suite.RelevantCodecs) > 0 && !contains(suite.RelevantCodecs, cfgCase.Codec) {
+		reasons = append(reasons, fmt.Sprintf("%s not in suite's relevant_codecs", cfgCase.Codec))
+	}
+	if len(suite.RelevantCompressions) > 0 && !contains(suite.RelevantCompressions, cfgCase.Compression) {
+		reasons = append(reasons, fmt.Sprintf("%s not in suite's relevant_compressions", cfgCase.Compression))
+	}
+	if suite.ReliesOnTls && !cfgCase.UseTLS {
+		reasons = append(reasons, "suite relies_on_tls but config case does not use TLS")
+	}
+	reasons = appendReliesOn(reasons, "relies_on_tls_client_certs", "TLS client certs",
+		suite.ReliesOnTlsClientCerts, cfgCase.UseTLSClientCerts)
+	reasons = appendReliesOn(reasons, "relies_on_connect_get", "Connect GET",
+		suite.ReliesOnConnectGet, cfgCase.UseConnectGET)
+	reasons = appendReliesOn(reasons, "relies_on_message_receive_limit", "a message receive limit",
+		suite.ReliesOnMessageReceiveLimit, cfgCase.UseMessageReceiveLimit)
+	if suite.ConnectVersionMode != cfgCase.ConnectVersionMode {
+		reasons = append(reasons, fmt.Sprintf("suite's connect_version_mode is %s but config case uses %s",
+			suite.ConnectVersionMode, cfgCase.ConnectVersionMode))
+	}
+	return reasons
+}
+
+// appendReliesOn appends a reason to the given slice if the given config case
+// property does not match what the suite relies on. Config cases must match
+// exactly: a suite that does not rely on a feature is not run with config
+// cases that use it.
+func appendReliesOn(reasons []string, field, feature string, suiteReliesOn, configCaseUses bool) []string {
+	switch {
+	case suiteReliesOn && !configCaseUses:
+		return append(reasons, fmt.Sprintf("suite %s but config case does not use %s", field, feature))
+	case !suiteReliesOn && configCaseUses:
+		return append(reasons, fmt.Sprintf("config case uses %s but suite does not set %s", feature, field))
+	default:
+		return reasons
+	}
+}
+
+func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix, suiteTags []string, testCases []*conformancev1.TestCase) error {
 	for i, testCase := range testCases {
 		if testCase.Request.TestName == "" {
 			return fmt.Errorf("test case #%d: test case has no name", i+1)
@@ -231,6 +248,7 @@ func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string,
 		}
 		testCase := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:errcheck,forcetypeassert
 		testCase.Request.TestName = fullName
+		testCase.Tags = mergeTags(suiteTags, testCase.Tags)
 		if cfgCase.UseTLS {
 			// to be replaced with actual cert provided by server
 			testCase.Request.ServerTlsCert = []byte("PLACEHOLDER")
@@ -246,6 +264,15 @@ func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string,
 			testCase.Request.ServerTlsCert = nil
 			testCase.Request.ClientTlsCreds = nil
 		}
+		if len(testCase.ExpectedResponseOverrides) > 0 {
+			applicable := make([]*conformancev1.TestCase_ExpectedResponseOverride, 0, len(testCase.ExpectedResponseOverrides))
+			for _, override := range testCase.ExpectedResponseOverrides {
+				if overrideApplies(override, cfgCase) {
+					applicable = append(applicable, override)
+				}
+			}
+			testCase.ExpectedResponseOverrides = applicable
+		}
 		testCase.Request.HttpVersion = cfgCase.Version
 		testCase.Request.Protocol = cfgCase.Protocol
 		testCase.Request.Codec = cfgCase.Codec
@@ -260,11 +287,17 @@ func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string,
 }
 
 func (lib *testCaseLibrary) populateExpectedResponses() error {
-	for _, testCase := range lib.testCases {
+	for _, name := range sortedKeys(lib.testCases) {
+		testCase := lib.testCases[name]
 		if err := populateExpectedResponse(testCase); err != nil {
 			return fmt.Errorf("failed to compute expected response for test case %q: %w",
 				testCase.Request.TestName, err)
 		}
+		applyExpectedResponseOverrides(testCase)
+		if err := checkResponseMatchers(testCase); err != nil {
+			return fmt.Errorf("test case %q has invalid response matchers: %w",
+				testCase.Request.TestName, err)
+		}
 	}
 	return nil
 }
@@ -355,14 +388,19 @@ func (lib *testCaseLibrary) filterGRPCImplTestCases(testCases []*conformancev1.T
 }
 
 type testCaseFilter struct {
-	run, noRun *testTrie
+	run, noRun     *testTrie
+	tags, skipTags []string
 }
 
-func newFilter(run, noRun *testTrie) *testCaseFilter {
-	if run == nil && noRun == nil {
+// newFilter returns a filter that accepts test cases whose names match the
+// run patterns (if any) and that have at least one of the given tags (if any).
+// Test cases whose names match the noRun patterns or that have any of the
+// given skip tags are rejected. It returns nil if there is no filtering.
+func newFilter(run, noRun *testTrie, tags, skipTags []string) *testCaseFilter {
+	if run == nil && noRun == nil && len(tags) == 0 && len(skipTags) == 0 {
 		return nil
 	}
-	return &testCaseFilter{run: run, noRun: noRun}
+	return &testCaseFilter{run: run, noRun: noRun, tags: tags, skipTags: skipTags}
 }
 
 func (f *testCaseFilter) accept(testCase *conformancev1.TestCase) bool {
@@ -375,11 +413,17 @@ func (f *testCaseFilter) accept(testCase *conformancev1.TestCase) bool {
 	if f.noRun != nil && f.noRun.matchPattern(testCase.Request.TestName) {
 		return false
 	}
+	if len(f.tags) > 0 && !hasAnyTag(testCase.Tags, f.tags) {
+		return false
+	}
+	if len(f.skipTags) > 0 && hasAnyTag(testCase.Tags, f.skipTags) {
+		return false
+	}
 	return true
 }
 
 func (f *testCaseFilter) apply(testCases []*conformancev1.TestCase) []*conformancev1.TestCase {
-	if f == nil || (f.run == nil && f.noRun == nil) {
+	if f == nil {
 		return testCases // no filtering
 	}
 	results := make([]*conformancev1.TestCase, 0, len(testCases))
@@ -419,7 +463,6 @@ type streamResponseDefiner interface {
 }
 
 func addGRPCMarkerToName(fullName, simpleName string, clientIsGRPCImpl, serverIsGRPCImpl bool) string {
-	prefix := strings.TrimSuffix(fullName, simpleName)
 	var elem string
 	switch {
 	case clientIsGRPCImpl && serverIsGRPCImpl:
@@ -429,7 +472,14 @@ func addGRPCMarkerToName(fullName, simpleName string, clientIsGRPCImpl, serverIs
 	case serverIsGRPCImpl:
 		elem = grpcServerImplMarker
 	}
-	return prefix + elem + "/" + simpleName
+	return addMarkerToName(fullName, simpleName, elem)
+}
+
+// addMarkerToName inserts the given marker into the given test case name, as
+// a separate name component just before the simple name of the test case.
+func addMarkerToName(fullName, simpleName, marker string) string {
+	prefix := strings.TrimSuffix(fullName, simpleName)
+	return prefix + marker + "/" + simpleName
 }
 
 // parseTestSuites processes the given file contents. The given map is keyed
@@ -438,13 +488,14 @@ func addGRPCMarkerToName(fullName, simpleName string, clientIsGRPCImpl, serverIs
 // see testsuites.LoadTestSuites.
 func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.TestSuite, error) {
 	allSuites := make(map[string]*conformancev1.TestSuite, len(testFileData))
-	for testFilePath, data := range testFileData {
-		opts := protoyaml.UnmarshalOptions{
-			Path: testFilePath,
+	for _, testFilePath := range sortedKeys(testFileData) {
+		data := testFileData[testFilePath]
+		suite, _, err := unmarshalTestSuite(testFilePath, data)
+		if err != nil {
+			return nil, err
 		}
-		suite := &conformancev1.TestSuite{}
-		if err := opts.Unmarshal(data, suite); err != nil {
-			return nil, internal.EnsureFileName(err, testFilePath)
+		if err := validateTags(suite.Tags); err != nil {
+			return nil, fmt.Errorf("%s: suite has invalid tags: %w", testFilePath, err)
 		}
 		for _, testCase := range suite.TestCases {
 			if testCase.Request.RawRequest != nil && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
@@ -464,6 +515,21 @@ func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.
 				return nil, fmt.Errorf("%s: test case %q specifies expand requests directive, but includes codecs other than CODEC_PROTO",
 					testFilePath, testCase.Request.TestName)
 			}
+			if err := validateResponseMatchers(testCase.ResponseMatchers); err != nil {
+				return nil, fmt.Errorf("%s: test case %q has invalid response matchers: %w",
+					testFilePath, testCase.Request.TestName, err)
+			}
+			if err := validateTags(testCase.Tags); err != nil {
+				return nil, fmt.Errorf("%s: test case %q has invalid tags: %w",
+					testFilePath, testCase.Request.TestName, err)
+			}
+			if err := validateExpectedResponseOverrides(testCase.ExpectedResponseOverrides); err != nil {
+				return nil, fmt.Errorf("%s: test case %q has invalid expected response overrides: %w",
+					testFilePath, testCase.Request.TestName, err)
+			}
+			if testCase.Severity == conformancev1.Severity_SEVERITY_UNSPECIFIED {
+				testCase.Severity = suite.Severity
+			}
 			if err := expandRequestData(testCase); err != nil {
 				return nil, fmt.Errorf("%s: failed to expand request sizes as directed for test case %q: %w",
 					testFilePath, testCase.Request.TestName, err)
@@ -474,6 +540,162 @@ func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.
 	return allSuites, nil
 }
 
+// validateResponseMatchers checks the given response matchers for problems
+// that do not depend on the expected response.
+func validateResponseMatchers(matchers *conformancev1.ResponseMatchers) error {
+	if matchers == nil {
+		return nil
+	}
+	var errs []error
+	if matchers.ErrorMessageRegex != "" {
+		if _, err := regexp.Compile(matchers.ErrorMessageRegex); err != nil {
+			errs = append(errs, fmt.Errorf("invalid error message regex: %w", err))
+		}
+		if matchers.ErrorMessagePrefix != "" {
+			errs = append(errs, errors.New("error message regex and prefix cannot both be specified"))
+		}
+	}
+	if contains(matchers.ErrorCodes, conformancev1.Code_CODE_UNSPECIFIED) {
+		errs = append(errs, errors.New("error codes include CODE_UNSPECIFIED"))
+	}
+	for _, name := range matchers.ResponseHeadersPresent {
+		if containsHeaderName(matchers.ResponseHeadersAbsent, name) {
+			errs = append(errs, fmt.Errorf("response header %q must be both present and absent", name))
+		}
+	}
+	for _, name := range matchers.ResponseTrailersPresent {
+		if containsHeaderName(matchers.ResponseTrailersAbsent, name) {
+			errs = append(errs, fmt.Errorf("response trailer %q must be both present and absent", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkResponseMatchers checks that the given test case's response matchers
+// are consistent with its expected response. This must be called after the
+// expected response has been populated.
+func checkResponseMatchers(testCase *conformancev1.TestCase) error {
+	matchers := testCase.ResponseMatchers
+	if matchers == nil {
+		return nil
+	}
+	expected := testCase.ExpectedResponse
+	var errs []error
+	if expected.GetError() == nil &&
+		(len(matchers.ErrorCodes) > 0 || matchers.ErrorMessageRegex != "" || matchers.ErrorMessagePrefix != "" ||
+			matchers.ErrorDetails != conformancev1.ResponseMatchers_ERROR_DETAILS_MATCH_UNSPECIFIED ||
+			matchers.ErrorMessageSeverity != conformancev1.Severity_SEVERITY_UNSPECIFIED) {
+		errs = append(errs, errors.New("error matchers are specified, but no error is expected"))
+	}
+	for _, hdr := range expected.GetResponseHeaders() {
+		if containsHeaderName(matchers.ResponseHeadersAbsent, hdr.Name) {
+			errs = append(errs, fmt.Errorf("response header %q is expected, but must be absent", hdr.Name))
+		}
+	}
+	for _, hdr := range expected.GetResponseTrailers() {
+		if containsHeaderName(matchers.ResponseTrailersAbsent, hdr.Name) {
+			errs = append(errs, fmt.Errorf("response trailer %q is expected, but must be absent", hdr.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateExpectedResponseOverrides checks the given overrides for problems
+// that do not depend on the permutation to which they are applied.
+func validateExpectedResponseOverrides(overrides []*conformancev1.TestCase_ExpectedResponseOverride) error {
+	var errs []error
+	for i, override := range overrides {
+		for _, err := range splitJoinedErrors(validateExpectedResponseOverride(override)) {
+			if err != nil {
+				errs = append(errs, fmt.Errorf("override #%d: %w", i+1, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateExpectedResponseOverride checks the given override for problems
+// that do not depend on the permutation to which it is applied.
+func validateExpectedResponseOverride(override *conformancev1.TestCase_ExpectedResponseOverride) error {
+	var errs []error
+	if len(override.Protocols) == 0 && len(override.HttpVersions) == 0 && len(override.Codecs) == 0 {
+		errs = append(errs, errors.New("must specify at least one protocol, HTTP version, or codec"))
+	}
+	if contains(override.Protocols, conformancev1.Protocol_PROTOCOL_UNSPECIFIED) {
+		errs = append(errs, errors.New("protocols include PROTOCOL_UNSPECIFIED"))
+	}
+	if contains(override.HttpVersions, conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED) {
+		errs = append(errs, errors.New("HTTP versions include HTTP_VERSION_UNSPECIFIED"))
+	}
+	if contains(override.Codecs, conformancev1.Codec_CODEC_UNSPECIFIED) {
+		errs = append(errs, errors.New("codecs include CODEC_UNSPECIFIED"))
+	}
+	fields := (&conformancev1.ClientResponseResult{}).ProtoReflect().Descriptor().Fields()
+	for _, name := range override.ClearFields {
+		if clientResponseResultField(fields, name) == nil {
+			errs = append(errs, fmt.Errorf("cannot clear unknown field %q", name))
+		}
+	}
+	if err := validateResponseMatchers(override.ResponseMatchers); err != nil {
+		errs = append(errs, fmt.Errorf("invalid response matchers: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// overrideApplies returns true if the given override applies to test cases
+// for the given config case.
+func overrideApplies(override *conformancev1.TestCase_ExpectedResponseOverride, cfgCase configCase) bool {
+	return (len(override.Protocols) == 0 || contains(override.Protocols, cfgCase.Protocol)) &&
+		(len(override.HttpVersions) == 0 || contains(override.HttpVersions, cfgCase.Version)) &&
+		(len(override.Codecs) == 0 || contains(override.Codecs, cfgCase.Codec))
+}
+
+// applyExpectedResponseOverrides applies the given test case's overrides
+// to its expected response. This must be called after the expected response
+// has been populated. The overrides must already have been filtered so that
+// they only include those that apply to the test case.
+func applyExpectedResponseOverrides(testCase *conformancev1.TestCase) {
+	expected := testCase.ExpectedResponse.ProtoReflect()
+	fields := expected.Descriptor().Fields()
+	for _, override := range testCase.ExpectedResponseOverrides {
+		for _, name := range override.ClearFields {
+			if field := clientResponseResultField(fields, name); field != nil {
+				expected.Clear(field)
+			}
+		}
+		if override.ExpectedResponse != nil {
+			patch := proto.Clone(override.ExpectedResponse).ProtoReflect()
+			patch.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+				expected.Set(field, value)
+				return true
+			})
+		}
+		if override.ResponseMatchers != nil {
+			testCase.ResponseMatchers = override.ResponseMatchers
+		}
+	}
+	// Overrides have been applied, so they are no longer needed.
+	testCase.ExpectedResponseOverrides = nil
+}
+
+// clientResponseResultField returns the field with the given name, which may
+// be either the field's name or its JSON name, or nil if there is no such field.
+func clientResponseResultField(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	if field := fields.ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return fields.ByJSONName(name)
+}
+
+func containsHeaderName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // expandRequestData expands the request_data field of RPC requests in the
 // given test case, per directives in the expand_requests test case field.
 func expandRequestData(testCase *conformancev1.TestCase) error {
@@ -522,6 +522,7 @@ func TestFilter(t *testing.T) {
 	testCases := []struct {
 		name                       string
 		runPatterns, noRunPatterns []string
+		tags, skipTags             []string
 		keepers                    []string
 	}{
 		{
@@ -561,6 +562,31 @@ func TestFilter(t *testing.T) {
 				"Timeout/foo/bar=baz/unary",
 			},
 		},
+		{
+			name:     "tags",
+			tags:     []string{"cancel", "timeout"},
+			skipTags: []string{"frobnitz"},
+			keepers: []string{
+				"Cancel/foo/bar=baz/unary",
+				"Cancel/foo/bar=baz/client stream",
+				"Cancel/foo/bar=baz/server stream",
+				"Cancel/foo/bar=baz/bidi stream",
+				"Timeout/foo/bar=baz/unary",
+				"Timeout/foo/bar=baz/client stream",
+				"Timeout/foo/bar=baz/server stream",
+				"Timeout/foo/bar=baz/bidi stream",
+			},
+		},
+		{
+			name:          "tags combined with patterns",
+			runPatterns:   []string{"**/unary"},
+			noRunPatterns: []string{"Basic/**"},
+			tags:          []string{"frobnitz"},
+			keepers: []string{
+				"Cancel/foo/bar=baz/(frobnitz)/unary",
+				"Timeout/foo/bar=baz/(frobnitz)/unary",
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		testCase := testCase
@@ -568,11 +594,19 @@ func TestFilter(t *testing.T) {
 			t.Parallel()
 			candidates := make([]*conformancev1.TestCase, len(allTestCaseNames))
 			for i, testCaseName := range allTestCaseNames {
-				candidates[i] = &conformancev1.TestCase{Request: &conformancev1.ClientCompatRequest{
-					TestName: testCaseName,
-				}}
+				suiteName, _, _ := strings.Cut(testCaseName, "/")
+				tags := []string{strings.ToLower(suiteName)}
+				if strings.Contains(testCaseName, "(frobnitz)") {
+					tags = append(tags, "frobnitz")
+				}
+				candidates[i] = &conformancev1.TestCase{
+					Request: &conformancev1.ClientCompatRequest{
+						TestName: testCaseName,
+					},
+					Tags: tags,
+				}
 			}
-			filter := newFilter(parsePatterns(testCase.runPatterns), parsePatterns(testCase.noRunPatterns))
+			filter := newFilter(parsePatterns(testCase.runPatterns), parsePatterns(testCase.noRunPatterns), testCase.tags, testCase.skipTags)
 			filtered := filter.apply(candidates)
 			assert.Len(t, filtered, len(testCase.keepers))
 			for i, testCaseName := range testCase.keepers {
@@ -1866,6 +1900,216 @@ func TestPopulateExpectedResponse(t *testing.T) {
 	}
 }
 
+func TestExpectedResponseOverrides(t *testing.T) {
+	t.Parallel()
+	testSuites, err := parseTestSuites(map[string][]byte{"overrides.yaml": []byte(`name: Overrides
+testCases:
+- request:
+    testName: unary
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+      responseDefinition:
+        responseHeaders:
+        - name: abc
+          value: ["xyz"]
+        error:
+          code: CODE_INTERNAL
+          message: oops
+  expectedResponseOverrides:
+  - protocols: [PROTOCOL_GRPC_WEB]
+    clearFields: [responseHeaders]
+    expectedResponse:
+      responseTrailers:
+      - name: abc
+        value: ["xyz"]
+  - protocols: [PROTOCOL_GRPC, PROTOCOL_GRPC_WEB]
+    codecs: [CODEC_JSON]
+    expectedResponse:
+      error:
+        code: CODE_UNKNOWN
+    responseMatchers:
+      errorMessagePrefix: "oo"
+`)})
+	require.NoError(t, err)
+
+	var configCases []configCase
+	for _, protocol := range []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT, conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB} {
+		for _, codec := range []conformancev1.Codec{conformancev1.Codec_CODEC_PROTO, conformancev1.Codec_CODEC_JSON} {
+			configCases = append(configCases, configCase{
+				Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
+				Protocol:    protocol,
+				Codec:       codec,
+				Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+				StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+			})
+		}
+	}
+	lib, err := newTestCaseLibrary(testSuites, configCases, conformancev1.TestSuite_TEST_MODE_CLIENT)
+	require.NoError(t, err)
+
+	headers := []*conformancev1.Header{{Name: "abc", Value: []string{"xyz"}}}
+	testCases := []struct {
+		name             string
+		expectedHeaders  []*conformancev1.Header
+		expectedTrailers []*conformancev1.Header
+		expectedCode     conformancev1.Code
+		expectMatchers   bool
+	}{
+		{
+			name:            "Overrides/HTTPVersion:2/Protocol:PROTOCOL_CONNECT/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedHeaders: headers,
+			expectedCode:    conformancev1.Code_CODE_INTERNAL,
+		},
+		{
+			name:            "Overrides/HTTPVersion:2/Protocol:PROTOCOL_CONNECT/Codec:CODEC_JSON/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedHeaders: headers,
+			expectedCode:    conformancev1.Code_CODE_INTERNAL,
+		},
+		{
+			name:            "Overrides/HTTPVersion:2/Protocol:PROTOCOL_GRPC/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedHeaders: headers,
+			expectedCode:    conformancev1.Code_CODE_INTERNAL,
+		},
+		{
+			name:            "Overrides/HTTPVersion:2/Protocol:PROTOCOL_GRPC/Codec:CODEC_JSON/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedHeaders: headers,
+			expectedCode:    conformancev1.Code_CODE_UNKNOWN,
+			expectMatchers:  true,
+		},
+		{
+			name:             "Overrides/HTTPVersion:2/Protocol:PROTOCOL_GRPC_WEB/Codec:CODEC_PROTO/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedTrailers: headers,
+			expectedCode:     conformancev1.Code_CODE_INTERNAL,
+		},
+		{
+			name:             "Overrides/HTTPVersion:2/Protocol:PROTOCOL_GRPC_WEB/Codec:CODEC_JSON/Compression:COMPRESSION_IDENTITY/TLS:false/unary",
+			expectedTrailers: headers,
+			expectedCode:     conformancev1.Code_CODE_UNKNOWN,
+			expectMatchers:   true,
+		},
+	}
+	require.Len(t, lib.testCases, len(testCases))
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			libCase := lib.testCases[testCase.name]
+			require.NotNil(t, libCase)
+			assert.Empty(t, libCase.ExpectedResponseOverrides)
+			expected := libCase.ExpectedResponse
+			assert.Empty(t, cmp.Diff(testCase.expectedHeaders, expected.ResponseHeaders, protocmp.Transform()))
+			assert.Empty(t, cmp.Diff(testCase.expectedTrailers, expected.ResponseTrailers, protocmp.Transform()))
+			assert.Equal(t, testCase.expectedCode, expected.GetError().GetCode())
+			if testCase.expectMatchers {
+				assert.Equal(t, "oo", libCase.GetResponseMatchers().GetErrorMessagePrefix())
+			} else {
+				assert.Nil(t, libCase.ResponseMatchers)
+			}
+		})
+	}
+}
+
+func TestValidateExpectedResponseOverrides(t *testing.T) {
+	t.Parallel()
+	overrides := []*conformancev1.TestCase_ExpectedResponseOverride{
+		{
+			Protocols: []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_GRPC},
+		},
+		{
+			ClearFields: []string{"response_headers", "responseTrailers", "foo"},
+		},
+		{
+			Codecs:           []conformancev1.Codec{conformancev1.Codec_CODEC_UNSPECIFIED},
+			ResponseMatchers: &conformancev1.ResponseMatchers{ErrorMessageRegex: "abc", ErrorMessagePrefix: "abc"},
+		},
+	}
+	err := validateExpectedResponseOverrides(overrides)
+	require.EqualError(t, err, `override #2: must specify at least one protocol, HTTP version, or codec
+override #2: cannot clear unknown field "foo"
+override #3: codecs include CODEC_UNSPECIFIED
+override #3: invalid response matchers: error message regex and prefix cannot both be specified`)
+}
+
+func TestResponseMatchers_Validation(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		matchers    string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "valid",
+			matchers: `{"errorCodes": ["CODE_NOT_FOUND"], "errorMessageRegex": "^not found", "responseHeadersPresent": ["abc"]}`,
+			expected: `{"error": {"code": 5}, "responseHeaders": [{"name": "abc", "value": ["1"]}]}`,
+		},
+		{
+			name:        "bad regex",
+			matchers:    `{"errorMessageRegex": "(abc"}`,
+			expected:    `{"error": {"code": 5}}`,
+			expectedErr: "invalid error message regex: error parsing regexp: missing closing ): `(abc`",
+		},
+		{
+			name:        "regex and prefix",
+			matchers:    `{"errorMessageRegex": "abc", "errorMessagePrefix": "abc"}`,
+			expected:    `{"error": {"code": 5}}`,
+			expectedErr: "error message regex and prefix cannot both be specified",
+		},
+		{
+			name:        "unspecified code",
+			matchers:    `{"errorCodes": ["CODE_UNSPECIFIED"]}`,
+			expected:    `{"error": {"code": 5}}`,
+			expectedErr: "error codes include CODE_UNSPECIFIED",
+		},
+		{
+			name:        "present and absent",
+			matchers:    `{"responseHeadersPresent": ["abc"], "responseHeadersAbsent": ["ABC"], "responseTrailersPresent": ["def"], "responseTrailersAbsent": ["def"]}`,
+			expected:    `{}`,
+			expectedErr: "response header \"abc\" must be both present and absent\nresponse trailer \"def\" must be both present and absent",
+		},
+		{
+			name:        "error matchers without error",
+			matchers:    `{"errorDetails": "ERROR_DETAILS_MATCH_CONTAINS"}`,
+			expected:    `{}`,
+			expectedErr: "error matchers are specified, but no error is expected",
+		},
+		{
+			name:        "error message severity without error",
+			matchers:    `{"errorMessageSeverity": "SEVERITY_SHOULD"}`,
+			expected:    `{}`,
+			expectedErr: "error matchers are specified, but no error is expected",
+		},
+		{
+			name:        "expected but absent",
+			matchers:    `{"responseHeadersAbsent": ["abc"], "responseTrailersAbsent": ["def"]}`,
+			expected:    `{"responseHeaders": [{"name": "Abc", "value": ["1"]}], "responseTrailers": [{"name": "def", "value": ["1"]}]}`,
+			expectedErr: "response header \"Abc\" is expected, but must be absent\nresponse trailer \"def\" is expected, but must be absent",
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			tc := &conformancev1.TestCase{ //nolint:varnamelen
+				ResponseMatchers: &conformancev1.ResponseMatchers{},
+				ExpectedResponse: &conformancev1.ClientResponseResult{},
+			}
+			require.NoError(t, protojson.Unmarshal([]byte(testCase.matchers), tc.ResponseMatchers))
+			require.NoError(t, protojson.Unmarshal([]byte(testCase.expected), tc.ExpectedResponse))
+			err := validateResponseMatchers(tc.ResponseMatchers)
+			if err == nil {
+				err = checkResponseMatchers(tc)
+			}
+			if testCase.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
 // asAnySlice converts the given variadic arg of proto messages to a slice of Any protos
 // and verifies there are no errors during the conversion.
 func asAnySlice(t *testing.T, msgs ...proto.Message) []*anypb.Any {
@@ -1878,3 +2122,87 @@ func asAnySlice(t *testing.T, msgs ...proto.Message) []*anypb.Any {
 	}
 	return arr
 }
+
+func TestParseTestSuites_Severity(t *testing.T) {
+	t.Parallel()
+	testSuites, err := parseTestSuites(map[string][]byte{
+		"default.yaml": []byte(`name: Default
+testCases:
+- request:
+    testName: inherited
+    streamType: STREAM_TYPE_UNARY
+- request:
+    testName: explicit
+    streamType: STREAM_TYPE_UNARY
+  severity: SEVERITY_SHOULD
+`),
+		"should.yaml": []byte(`name: Should
+severity: SEVERITY_SHOULD
+testCases:
+- request:
+    testName: inherited
+    streamType: STREAM_TYPE_UNARY
+- request:
+    testName: explicit
+    streamType: STREAM_TYPE_UNARY
+  severity: SEVERITY_MUST
+`),
+	})
+	require.NoError(t, err)
+	severities := map[string]conformancev1.Severity{}
+	for _, suite := range testSuites {
+		for _, testCase := range suite.TestCases {
+			severities[suite.Name+"/"+testCase.Request.TestName] = testCase.Severity
+		}
+	}
+	assert.Equal(t, map[string]conformancev1.Severity{
+		"Default/inherited": conformancev1.Severity_SEVERITY_UNSPECIFIED,
+		"Default/explicit":  conformancev1.Severity_SEVERITY_SHOULD,
+		"Should/inherited":  conformancev1.Severity_SEVERITY_SHOULD,
+		"Should/explicit":   conformancev1.Severity_SEVERITY_MUST,
+	}, severities)
+}
+
+func TestNewTestCaseLibrary_DeterministicErrors(t *testing.T) {
+	t.Parallel()
+	_, err := parseTestSuites(map[string][]byte{
+		"b.yaml": []byte("name: B\ntags: [\"b b\"]\n"),
+		"a.yaml": []byte("name: A\ntags: [\"a a\"]\n"),
+		"c.yaml": []byte("name: C\ntags: [\"c c\"]\n"),
+	})
+	require.EqualError(t, err, `a.yaml: suite has invalid tags: tag "a a" must not contain whitespace or commas`)
+
+	testSuites, err := parseTestSuites(map[string][]byte{"matchers.yaml": []byte(`name: Matchers
+testCases:
+- request:
+    testName: unary
+    streamType: STREAM_TYPE_UNARY
+    requestMessages:
+    - "@type": type.googleapis.com/connectrpc.conformance.v1.UnaryRequest
+      responseDefinition:
+        responseHeaders:
+        - name: abc
+          value: ["xyz"]
+  responseMatchers:
+    responseHeadersAbsent: [abc]
+`)})
+	require.NoError(t, err)
+	var configCases []configCase
+	for _, protocol := range []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_GRPC_WEB, conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_CONNECT} {
+		for _, codec := range []conformancev1.Codec{conformancev1.Codec_CODEC_JSON, conformancev1.Codec_CODEC_PROTO} {
+			configCases = append(configCases, configCase{
+				Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
+				Protocol:    protocol,
+				Codec:       codec,
+				Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+				StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+			})
+		}
+	}
+	// Every config case produces an invalid test case, but the error
+	// should always be reported for the same one.
+	for i := 0; i < 10; i++ {
+		_, err := newTestCaseLibrary(testSuites, configCases, conformancev1.TestSuite_TEST_MODE_CLIENT)
+		require.EqualError(t, err, `test case "Matchers/HTTPVersion:2/Protocol:PROTOCOL_CONNECT/Codec:CODEC_JSON/Compression:COMPRESSION_IDENTITY/TLS:false/unary" has invalid response matchers: response header "abc" is expected, but must be absent`)
+	}
+}
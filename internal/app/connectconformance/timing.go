@@ -0,0 +1,113 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"sort"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// numSlowestToReport is the number of test cases and server configurations
+// that are shown in the timing summary at the end of a report.
+const numSlowestToReport = 10
+
+// testTiming records when the request for a test case was sent to the
+// client and when its result was received.
+type testTiming struct {
+	sent, received time.Time
+}
+
+func (t testTiming) duration() time.Duration {
+	return t.received.Sub(t.sent)
+}
+
+// serverTiming records how long a server process took to start and
+// how long it took to run all of its test cases.
+type serverTiming struct {
+	// description of the server and its configuration
+	name string
+	// number of test cases run against the server
+	numCases int
+	// time from starting the server process until it responded
+	// with its address
+	startup time.Duration
+	// time from starting the server process until all test cases
+	// have completed
+	total time.Duration
+}
+
+// recordTiming records when the request for the named test case was
+// sent to the client and when its result was received.
+func (r *testResults) recordTiming(testCase string, sent, received time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings[testCase] = testTiming{sent: sent, received: received}
+}
+
+// recordServerTiming records the startup and total run time for a server.
+func (r *testResults) recordServerTiming(timing serverTiming) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.serverTimings = append(r.serverTimings, timing)
+}
+
+// printSlowestLocked prints the limit slowest test cases and server
+// configurations. Nothing is printed if no timings were recorded.
+func (r *testResults) printSlowestLocked(printer internal.Printer, limit int) {
+	if len(r.timings) > 0 {
+		names := make([]string, 0, len(r.timings))
+		for name := range r.timings {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			di, dj := r.timings[names[i]].duration(), r.timings[names[j]].duration()
+			if di != dj {
+				return di > dj
+			}
+			return names[i] < names[j]
+		})
+		if len(names) > limit {
+			names = names[:limit]
+		}
+		printer.Printf("\nSlowest %d test case(s):", len(names))
+		for _, name := range names {
+			printer.Printf("  %10v  %s", roundDuration(r.timings[name].duration()), name)
+		}
+	}
+	if len(r.serverTimings) > 0 {
+		servers := make([]serverTiming, len(r.serverTimings))
+		copy(servers, r.serverTimings)
+		sort.Slice(servers, func(i, j int) bool {
+			if servers[i].total != servers[j].total {
+				return servers[i].total > servers[j].total
+			}
+			return servers[i].name < servers[j].name
+		})
+		if len(servers) > limit {
+			servers = servers[:limit]
+		}
+		printer.Printf("\nSlowest %d server config(s):", len(servers))
+		for _, server := range servers {
+			printer.Printf("  %10v  (startup %v, %d tests)  %s",
+				roundDuration(server.total), roundDuration(server.startup), server.numCases, server.name)
+		}
+	}
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
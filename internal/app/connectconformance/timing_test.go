@@ -0,0 +1,61 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResults_PrintSlowest(t *testing.T) {
+	t.Parallel()
+	results := newResults(makeKnownFailing(), makeKnownFlaky(), nil)
+
+	// Nothing printed if there are no timings.
+	printer := &internal.SimplePrinter{}
+	results.printSlowestLocked(printer, 2)
+	assert.Empty(t, printer.Messages)
+
+	start := time.Now()
+	results.recordTiming("foo/bar/1", start, start.Add(10*time.Millisecond))
+	results.recordTiming("foo/bar/2", start, start.Add(3*time.Second))
+	results.recordTiming("foo/bar/3", start, start.Add(200*time.Millisecond))
+	results.recordServerTiming(serverTiming{
+		name:     "server config A",
+		numCases: 10,
+		startup:  100 * time.Millisecond,
+		total:    2 * time.Second,
+	})
+	results.recordServerTiming(serverTiming{
+		name:     "server config B",
+		numCases: 5,
+		startup:  1500 * time.Microsecond,
+		total:    4 * time.Second,
+	})
+
+	printer = &internal.SimplePrinter{}
+	results.printSlowestLocked(printer, 2)
+	assert.Equal(t, []string{
+		"\nSlowest 2 test case(s):\n",
+		"          3s  foo/bar/2\n",
+		"       200ms  foo/bar/3\n",
+		"\nSlowest 2 server config(s):\n",
+		"          4s  (startup 2ms, 5 tests)  server config B\n",
+		"          2s  (startup 100ms, 10 tests)  server config A\n",
+	}, printer.Messages)
+}
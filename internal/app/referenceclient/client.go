@@ -141,6 +141,7 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 					// clear out reference-mode-specific details
 					result.HttpStatusCode = nil
 					result.Feedback = nil
+					result.RecommendationFeedback = nil
 				}
 				resp.Result = &conformancev1.ClientCompatResponse_Response{
 					Response: result,
@@ -155,15 +155,16 @@ func (i *invoker) unary(
 		}
 	}
 
-	statusCode, feedback := i.examineWireDetails(ctx)
+	statusCode, feedback, recommendations := i.examineWireDetails(ctx)
 
 	return &conformancev1.ClientResponseResult{
-		ResponseHeaders:  headers,
-		ResponseTrailers: trailers,
-		Payloads:         payloads,
-		Error:            protoErr,
-		HttpStatusCode:   statusCode,
-		Feedback:         feedback,
+		ResponseHeaders:        headers,
+		ResponseTrailers:       trailers,
+		Payloads:               payloads,
+		Error:                  protoErr,
+		HttpStatusCode:         statusCode,
+		Feedback:               feedback,
+		RecommendationFeedback: recommendations,
 	}, nil
 }
 
@@ -210,15 +211,16 @@ func (i *invoker) idempotentUnary(
 		trailers = internal.ConvertToProtoHeader(resp.Trailer())
 	}
 
-	statusCode, feedback := i.examineWireDetails(ctx)
+	statusCode, feedback, recommendations := i.examineWireDetails(ctx)
 
 	return &conformancev1.ClientResponseResult{
-		ResponseHeaders:  headers,
-		ResponseTrailers: trailers,
-		Payloads:         payloads,
-		Error:            protoErr,
-		HttpStatusCode:   statusCode,
-		Feedback:         feedback,
+		ResponseHeaders:        headers,
+		ResponseTrailers:       trailers,
+		Payloads:               payloads,
+		Error:                  protoErr,
+		HttpStatusCode:         statusCode,
+		Feedback:               feedback,
+		RecommendationFeedback: recommendations,
 	}, nil
 }
 
@@ -270,7 +272,7 @@ func (i *invoker) serverStream(
 			// Read headers and trailers from the stream
 			result.ResponseHeaders = internal.ConvertToProtoHeader(stream.ResponseHeader())
 			result.ResponseTrailers = internal.ConvertToProtoHeader(stream.ResponseTrailer())
-			result.HttpStatusCode, result.Feedback = i.examineWireDetails(ctx)
+			result.HttpStatusCode, result.Feedback, result.RecommendationFeedback = i.examineWireDetails(ctx)
 		}
 	}()
 
@@ -371,16 +373,17 @@ func (i *invoker) clientStream(
 		trailers = internal.ConvertToProtoHeader(resp.Trailer())
 	}
 
-	statusCode, feedback := i.examineWireDetails(ctx)
+	statusCode, feedback, recommendations := i.examineWireDetails(ctx)
 
 	return &conformancev1.ClientResponseResult{
-		ResponseHeaders:   headers,
-		ResponseTrailers:  trailers,
-		Payloads:          payloads,
-		NumUnsentRequests: int32(numUnsent),
-		Error:             protoErr,
-		HttpStatusCode:    statusCode,
-		Feedback:          feedback,
+		ResponseHeaders:        headers,
+		ResponseTrailers:       trailers,
+		Payloads:               payloads,
+		NumUnsentRequests:      int32(numUnsent),
+		Error:                  protoErr,
+		HttpStatusCode:         statusCode,
+		Feedback:               feedback,
+		RecommendationFeedback: recommendations,
 	}, nil
 }
 
@@ -409,7 +412,7 @@ func (i *invoker) bidiStream(
 			// Read headers and trailers from the stream
 			result.ResponseHeaders = internal.ConvertToProtoHeader(stream.ResponseHeader())
 			result.ResponseTrailers = internal.ConvertToProtoHeader(stream.ResponseTrailer())
-			result.HttpStatusCode, result.Feedback = i.examineWireDetails(ctx)
+			result.HttpStatusCode, result.Feedback, result.RecommendationFeedback = i.examineWireDetails(ctx)
 		}
 	}()
 
@@ -538,12 +541,13 @@ func (i *invoker) unimplemented(
 	// Invoke the Unary call
 	_, err := i.client.Unimplemented(ctx, request)
 
-	statusCode, feedback := i.examineWireDetails(ctx)
+	statusCode, feedback, recommendations := i.examineWireDetails(ctx)
 
 	return &conformancev1.ClientResponseResult{
-		Error:          internal.ConvertErrorToProtoError(err),
-		HttpStatusCode: statusCode,
-		Feedback:       feedback,
+		Error:                  internal.ConvertErrorToProtoError(err),
+		HttpStatusCode:         statusCode,
+		Feedback:               feedback,
+		RecommendationFeedback: recommendations,
 	}, nil
 }
 
@@ -554,17 +558,16 @@ func (i *invoker) withWireCapture(ctx context.Context) context.Context {
 	return withWireCapture(ctx)
 }
 
-func (i *invoker) examineWireDetails(ctx context.Context) (*int32, []string) {
+func (i *invoker) examineWireDetails(ctx context.Context) (statusCode *int32, feedback, recommendations []string) {
 	if !i.referenceMode {
-		return nil, nil
+		return nil, nil, nil
 	}
-	printer := &internal.SimplePrinter{}
-	statusCode, ok := examineWireDetails(ctx, printer)
-	var statusCodePtr *int32
+	printer := &wireDetailsPrinter{}
+	code, ok := examineWireDetails(ctx, printer)
 	if ok {
-		statusCodePtr = proto.Int32(int32(statusCode))
+		statusCode = proto.Int32(int32(code))
 	}
-	return statusCodePtr, printer.Messages
+	return statusCode, printer.Messages, printer.Recommendations
 }
 
 // userAgentClientInterceptor adds to the user-agent header on outgoing requests.
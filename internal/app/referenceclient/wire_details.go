@@ -143,12 +143,25 @@ func setWireTrace(ctx context.Context, trace tracer.Trace) {
 	close(wrapper.traceAvailable)
 }
 
+// wireDetailsPrinter accumulates feedback about wire details. Feedback about
+// behavior that is only recommended, not required, is accumulated separately
+// via Recommendf, so that the test runner can report it as a warning.
+type wireDetailsPrinter struct {
+	internal.SimplePrinter
+	Recommendations []string
+}
+
+// Recommendf is like Printf, but for feedback about recommended behavior.
+func (p *wireDetailsPrinter) Recommendf(msg string, args ...any) {
+	p.Recommendations = append(p.Recommendations, fmt.Sprintf(msg, args...))
+}
+
 // examineWireDetails examines certain wire details of the call and returns the
 // HTTP status code (or if there is not one). Feedback about the wire details will
 // be printed to the given printer. This also records errors to the given printer
 // if the given context was never configured using withWireCapture or if the wire
 // details are not available within 1 second of the call.
-func examineWireDetails(ctx context.Context, printer internal.Printer) (statusCode int, ok bool) {
+func examineWireDetails(ctx context.Context, printer *wireDetailsPrinter) (statusCode int, ok bool) {
 	wrapper, ok := ctx.Value(wireCtxKey{}).(*wireWrapper)
 	if !ok {
 		printer.Printf("unable to examine wire details: call context not configured (no wire wrapper found).")
@@ -455,7 +468,7 @@ func examineConnectEndStream(endStreamJSON json.RawMessage, printer internal.Pri
 	}
 }
 
-func examineGRPCEndStream(endStream string, printer internal.Printer) {
+func examineGRPCEndStream(endStream string, printer *wireDetailsPrinter) {
 	// We break it using just LF, so we can handle alternate line ending. But we then complain
 	// below if CRLF isn't used.
 	endStreamLines := strings.Split(endStream, "\n")
@@ -522,16 +535,16 @@ func examineGRPCEndStream(endStream string, printer internal.Printer) {
 	}
 	if blankLines > 0 {
 		if blankLines == 1 && blankLineAtEnd {
-			printer.Printf("grpc-web trailers ends in extra blank line")
+			printer.Recommendf("grpc-web trailers ends in extra blank line")
 		} else {
 			printer.Printf("grpc-web trailers include blank lines")
 		}
 	}
 	if linesWithoutCR > 0 {
-		printer.Printf("grpc-web trailers have lines with LF line ending instead of CRLF")
+		printer.Recommendf("grpc-web trailers have lines with LF line ending instead of CRLF")
 	}
 	if !endsInCRLF {
-		printer.Printf("grpc-web trailers should end with CRLF but does not")
+		printer.Recommendf("grpc-web trailers should end with CRLF but does not")
 	}
 }
 
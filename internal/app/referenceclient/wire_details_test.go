@@ -26,7 +26,6 @@ import (
 	"strings"
 	"testing"
 
-	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
 	"connectrpc.com/connect"
@@ -322,7 +321,7 @@ func TestExamineConnectError(t *testing.T) {
 			req.Header().Set("x-test-case-name", "foo") // needed to enable tracing
 			_, err := client.Unary(ctx, req)
 			require.Error(t, err)
-			printer := &internal.SimplePrinter{}
+			printer := &wireDetailsPrinter{}
 			examineWireDetails(ctx, printer)
 			if len(testCase.expectedFeedback) == 0 {
 				assert.Empty(t, printer.Messages)
@@ -478,7 +477,7 @@ func TestExamineConnectEndStream(t *testing.T) {
 			stream.RequestHeader().Set("x-test-case-name", "foo") // needed to enable tracing
 			_, err := stream.CloseAndReceive()
 			require.Error(t, err)
-			printer := &internal.SimplePrinter{}
+			printer := &wireDetailsPrinter{}
 			examineWireDetails(ctx, printer)
 			if len(testCase.expectedFeedback) == 0 {
 				assert.Empty(t, printer.Messages)
@@ -503,10 +502,11 @@ func TestExamineConnectEndStream(t *testing.T) {
 func TestExamineGRPCEndStream(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
-		name             string
-		compressed       bool
-		endStream        string
-		expectedFeedback []string
+		name                    string
+		compressed              bool
+		endStream               string
+		expectedFeedback        []string
+		expectedRecommendations []string
 	}{
 		{
 			name: "correct",
@@ -549,10 +549,19 @@ func TestExamineGRPCEndStream(t *testing.T) {
 			endStream: "grpc-status: 6\r\n" +
 				"grpc-message: foo\r\n" +
 				"blah-blah: foobar",
-			expectedFeedback: []string{
+			expectedRecommendations: []string{
 				"grpc-web trailers should end with CRLF but does not",
 			},
 		},
+		{
+			name: "lf line endings",
+			endStream: "grpc-status: 6\n" +
+				"grpc-message: foo\n" +
+				"blah-blah: foobar\n",
+			expectedRecommendations: []string{
+				"grpc-web trailers have lines with LF line ending instead of CRLF",
+			},
+		},
 		{
 			name: "key without value",
 			endStream: "grpc-status: 6\r\n" +
@@ -568,7 +577,7 @@ func TestExamineGRPCEndStream(t *testing.T) {
 				"grpc-message: foo\r\n" +
 				"blah-blah: foobar\r\n" +
 				"\r\n",
-			expectedFeedback: []string{
+			expectedRecommendations: []string{
 				"grpc-web trailers ends in extra blank line",
 			},
 		},
@@ -647,10 +656,13 @@ func TestExamineGRPCEndStream(t *testing.T) {
 			req.Header().Set("x-test-case-name", "foo") // needed to enable tracing
 			_, err := client.Unary(ctx, req)
 			require.Error(t, err)
-			printer := &internal.SimplePrinter{}
+			printer := &wireDetailsPrinter{}
 			examineWireDetails(ctx, printer)
+			assert.Empty(t, cmp.Diff(testCase.expectedRecommendations, printer.Recommendations))
 			if len(testCase.expectedFeedback) == 0 {
-				assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err), "unexpected error: %v", err)
+				if len(testCase.expectedRecommendations) == 0 {
+					assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err), "unexpected error: %v", err)
+				}
 				assert.Empty(t, printer.Messages)
 			} else {
 				// When there's feedback, the connect-go client may complain about the end-stream message
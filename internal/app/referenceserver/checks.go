@@ -15,7 +15,9 @@
 package referenceserver
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -85,6 +87,12 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 		if expectedMethod, _ := getHeader(req.Header, "x-expect-http-method", feedback); req.Method != expectedMethod {
 			feedback.Printf("expected HTTP method %q, got %q", expectedMethod, req.Method)
 		}
+		// Clients should identify themselves, but raw requests from test cases
+		// may not, so the test runner says when to expect a user-agent header.
+		if expectUserAgent, _ := getHeader(req.Header, "x-expect-user-agent", feedback); expectUserAgent == "true" &&
+			req.Header.Get("user-agent") == "" {
+			feedback.Recommendf("client should identify itself with a user-agent header")
+		}
 
 		handler.ServeHTTP(respWriter, req)
 
@@ -151,7 +159,7 @@ func checkProtocol(expected conformancev1.Protocol, req *http.Request, feedback
 	if expected != actual {
 		feedback.Printf("expected protocol %v; instead got %v", expected, actual)
 	} else if expected == conformancev1.Protocol_PROTOCOL_GRPC && req.Header.Get("TE") != "trailers" {
-		feedback.Printf("gRPC protocol client should use 'te: trailers' header to indicate to proxies that it expects trailer")
+		feedback.Recommendf("gRPC protocol client should use 'te: trailers' header to indicate to proxies that it expects trailer")
 	}
 }
 
@@ -174,7 +182,7 @@ func checkCodec(expected conformancev1.Codec, req *http.Request, feedback *feedb
 	case req.Method == http.MethodGet:
 		// GET requests should not have a Content-Type header
 		if hasContentType {
-			feedback.Printf("content-type header should not appear with method GET")
+			feedback.Recommendf("content-type header should not appear with method GET")
 		}
 		// Servers should test for an empty request body by attempting a read.
 		// If no body is present, it should return an immediate EOF.
@@ -301,11 +309,33 @@ func getQueryParam(values url.Values, paramName string, feedback *feedbackPrinte
 	return values.Get(paramName), len(paramVals) > 0
 }
 
+// feedbackPrinter prints feedback about the client under test to stderr,
+// where the test runner looks for it. See internal.Feedback.
 type feedbackPrinter struct {
 	p            internal.Printer
 	testCaseName string
 }
 
 func (p *feedbackPrinter) Printf(format string, args ...any) {
-	p.p.PrefixPrintf(p.testCaseName, format, args...)
+	p.print(conformancev1.Severity_SEVERITY_MUST, format, args...)
+}
+
+// Recommendf is like Printf, but for feedback about behavior that is only
+// recommended, not required. Such feedback is reported as a warning unless
+// the conformance runner is in strict mode.
+func (p *feedbackPrinter) Recommendf(format string, args ...any) {
+	p.print(conformancev1.Severity_SEVERITY_SHOULD, format, args...)
+}
+
+func (p *feedbackPrinter) print(severity conformancev1.Severity, format string, args ...any) {
+	data, err := json.Marshal(&internal.Feedback{
+		TestName: p.testCaseName,
+		Message:  fmt.Sprintf(format, args...),
+		Severity: severity,
+	})
+	if err != nil {
+		p.p.PrefixPrintf(p.testCaseName, "failed to marshal feedback: %v", err)
+		return
+	}
+	p.p.Printf("%s", data)
 }
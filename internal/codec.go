@@ -51,6 +51,13 @@ func NewCodec(json bool) Codec {
 	return &protoCodec{}
 }
 
+// NewJSONLinesCodec returns a new Codec that uses the JSON format and writes
+// each message on a single line. Since the messages are decoded with a JSON
+// stream decoder, it can read messages that span multiple lines, too.
+func NewJSONLinesCodec() Codec {
+	return &jsonCodec{}
+}
+
 // jsonCodec marshals and unmarshals the JSON format.
 type jsonCodec struct {
 	protojson.MarshalOptions
@@ -0,0 +1,30 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+
+// Feedback is a problem that the reference server observed in a request
+// from a client under test. The reference server writes each one to stderr
+// as a single line of JSON, so that the test runner can tell it apart from
+// other output and attribute it to a test case.
+type Feedback struct {
+	TestName string `json:"testName"`
+	Message  string `json:"message"`
+	// Whether the problem violates a requirement (SEVERITY_MUST) or only
+	// a recommendation (SEVERITY_SHOULD). If unspecified, it is a
+	// requirement.
+	Severity conformancev1.Severity `json:"severity,omitempty"`
+}
@@ -34,6 +34,68 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Severity indicates whether a test case checks a requirement of a protocol
+// or just a recommendation. The severity of a test case applies to all checks
+// of its response, except that a mismatched error message may have a
+// different severity (see ResponseMatchers.error_message_severity). Feedback
+// about a test case from the reference client or reference server has its
+// own severity.
+type Severity int32
+
+const (
+	// Unspecified severity is the same as SEVERITY_MUST, unless it is
+	// inherited from an enclosing suite.
+	Severity_SEVERITY_UNSPECIFIED Severity = 0
+	// Failures are violations of requirements ("MUST" in the specs) and
+	// cause the test run to fail.
+	Severity_SEVERITY_MUST Severity = 1
+	// Failures are violations of recommendations ("SHOULD" in the specs).
+	// They are reported as warnings and do not cause the test run to fail,
+	// unless the conformance runner is run with the `--strict` flag.
+	Severity_SEVERITY_SHOULD Severity = 2
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_MUST",
+		2: "SEVERITY_SHOULD",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_MUST":        1,
+		"SEVERITY_SHOULD":      2,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[0].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[0]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{0}
+}
+
 type TestSuite_TestMode int32
 
 const (
@@ -76,11 +138,11 @@ func (x TestSuite_TestMode) String() string {
 }
 
 func (TestSuite_TestMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_connectrpc_conformance_v1_suite_proto_enumTypes[0].Descriptor()
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[1].Descriptor()
 }
 
 func (TestSuite_TestMode) Type() protoreflect.EnumType {
-	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[0]
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[1]
 }
 
 func (x TestSuite_TestMode) Number() protoreflect.EnumNumber {
@@ -131,11 +193,11 @@ func (x TestSuite_ConnectVersionMode) String() string {
 }
 
 func (TestSuite_ConnectVersionMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_connectrpc_conformance_v1_suite_proto_enumTypes[1].Descriptor()
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[2].Descriptor()
 }
 
 func (TestSuite_ConnectVersionMode) Type() protoreflect.EnumType {
-	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[1]
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[2]
 }
 
 func (x TestSuite_ConnectVersionMode) Number() protoreflect.EnumNumber {
@@ -147,6 +209,61 @@ func (TestSuite_ConnectVersionMode) EnumDescriptor() ([]byte, []int) {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{0, 1}
 }
 
+type ResponseMatchers_ErrorDetailsMatch int32
+
+const (
+	// The actual error must have exactly the expected details, in the
+	// same order.
+	ResponseMatchers_ERROR_DETAILS_MATCH_UNSPECIFIED ResponseMatchers_ErrorDetailsMatch = 0
+	// The actual error must have exactly the expected details, but they
+	// may be in any order.
+	ResponseMatchers_ERROR_DETAILS_MATCH_UNORDERED ResponseMatchers_ErrorDetailsMatch = 1
+	// The actual error must have at least the expected details, in any
+	// order. It may also have other details.
+	ResponseMatchers_ERROR_DETAILS_MATCH_CONTAINS ResponseMatchers_ErrorDetailsMatch = 2
+)
+
+// Enum value maps for ResponseMatchers_ErrorDetailsMatch.
+var (
+	ResponseMatchers_ErrorDetailsMatch_name = map[int32]string{
+		0: "ERROR_DETAILS_MATCH_UNSPECIFIED",
+		1: "ERROR_DETAILS_MATCH_UNORDERED",
+		2: "ERROR_DETAILS_MATCH_CONTAINS",
+	}
+	ResponseMatchers_ErrorDetailsMatch_value = map[string]int32{
+		"ERROR_DETAILS_MATCH_UNSPECIFIED": 0,
+		"ERROR_DETAILS_MATCH_UNORDERED":   1,
+		"ERROR_DETAILS_MATCH_CONTAINS":    2,
+	}
+)
+
+func (x ResponseMatchers_ErrorDetailsMatch) Enum() *ResponseMatchers_ErrorDetailsMatch {
+	p := new(ResponseMatchers_ErrorDetailsMatch)
+	*p = x
+	return p
+}
+
+func (x ResponseMatchers_ErrorDetailsMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResponseMatchers_ErrorDetailsMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[3].Descriptor()
+}
+
+func (ResponseMatchers_ErrorDetailsMatch) Type() protoreflect.EnumType {
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[3]
+}
+
+func (x ResponseMatchers_ErrorDetailsMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResponseMatchers_ErrorDetailsMatch.Descriptor instead.
+func (ResponseMatchers_ErrorDetailsMatch) EnumDescriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{2, 0}
+}
+
 // TestSuite represents a set of conformance test cases. This is also the schema
 // used for the structure of a YAML test file. Each YAML file represents a test
 // suite, which can contain numerous cases. Each test suite has various properties
@@ -195,6 +312,16 @@ type TestSuite struct {
 	// size of received messages. When true, mode should be set to indicate
 	// whether it is the client or the server that must support the limit.
 	ReliesOnMessageReceiveLimit bool `protobuf:"varint,12,opt,name=relies_on_message_receive_limit,json=reliesOnMessageReceiveLimit,proto3" json:"relies_on_message_receive_limit,omitempty"`
+	// Tags that categorize the cases in this suite, such as "cancellation"
+	// or "trailers". These apply to every test case in the suite, in addition
+	// to any tags on the test case itself. Tags can be used to select or skip
+	// test cases, via the `--tag` and `--skip-tag` flags to the conformance
+	// runner, and to mark test cases as known to fail.
+	Tags []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The severity of failures of the cases in this suite. If unspecified,
+	// failures are violations of requirements (SEVERITY_MUST). Test cases
+	// can override this via TestCase.severity.
+	Severity Severity `protobuf:"varint,14,opt,name=severity,proto3,enum=connectrpc.conformance.v1.Severity" json:"severity,omitempty"`
 }
 
 func (x *TestSuite) Reset() {
@@ -313,6 +440,20 @@ func (x *TestSuite) GetReliesOnMessageReceiveLimit() bool {
 	return false
 }
 
+func (x *TestSuite) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TestSuite) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
 type TestCase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -360,6 +501,43 @@ type TestCase struct {
 	// Specifying an expected response explicitly in test definitions will override
 	// the auto-generation of the test runner.
 	ExpectedResponse *ClientResponseResult `protobuf:"bytes,3,opt,name=expected_response,json=expectedResponse,proto3" json:"expected_response,omitempty"`
+	// When non-empty, this test case is a template that is expanded into one
+	// test case for every combination of the values of these axes. For
+	// example, a matrix with an axis of three error codes and an axis of two
+	// header names expands into six test cases.
+	//
+	// In each expanded test case, every occurrence of "${name}" in a string
+	// value anywhere in the request, expand_requests, or expected_response
+	// is replaced by that axis's value. This includes values of enum fields,
+	// so a field like "code: ${code}" can be used with an axis named "code"
+	// whose values are enum value names.
+	//
+	// If the test_name does not refer to an axis, the axis's value is appended
+	// to the name, separated by a slash, so that each expanded test case has
+	// a distinct name. Axes are expanded in the order they are defined: the
+	// values of the first axis vary the slowest.
+	Matrix []*TestCase_MatrixAxis `protobuf:"bytes,4,rep,name=matrix,proto3" json:"matrix,omitempty"`
+	// Relaxes how the actual response is compared to the expected response,
+	// for cases where the specification allows implementations some latitude.
+	// Without this, the comparison is exact (apart from canonicalization of
+	// header values). This applies to both an explicit expected_response and
+	// one that is auto-generated.
+	ResponseMatchers *ResponseMatchers `protobuf:"bytes,5,opt,name=response_matchers,json=responseMatchers,proto3" json:"response_matchers,omitempty"`
+	// Patches to the expected response that apply only to some permutations
+	// of this test case. This allows a single test case to cover behavior that
+	// legitimately differs between protocols, HTTP versions, or codecs, instead
+	// of having to split it into separate suites.
+	//
+	// The expected response (whether explicit or auto-generated) is computed
+	// first. Then every override that matches the permutation is applied, in
+	// the order they are defined.
+	ExpectedResponseOverrides []*TestCase_ExpectedResponseOverride `protobuf:"bytes,6,rep,name=expected_response_overrides,json=expectedResponseOverrides,proto3" json:"expected_response_overrides,omitempty"`
+	// Tags that categorize this test case. The test case also has all of the
+	// tags of its suite. See TestSuite.tags.
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The severity of failures of this test case. If unspecified, the
+	// severity of the suite is used.
+	Severity Severity `protobuf:"varint,8,opt,name=severity,proto3,enum=connectrpc.conformance.v1.Severity" json:"severity,omitempty"`
 }
 
 func (x *TestCase) Reset() {
@@ -415,6 +593,181 @@ func (x *TestCase) GetExpectedResponse() *ClientResponseResult {
 	return nil
 }
 
+func (x *TestCase) GetMatrix() []*TestCase_MatrixAxis {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *TestCase) GetResponseMatchers() *ResponseMatchers {
+	if x != nil {
+		return x.ResponseMatchers
+	}
+	return nil
+}
+
+func (x *TestCase) GetExpectedResponseOverrides() []*TestCase_ExpectedResponseOverride {
+	if x != nil {
+		return x.ExpectedResponseOverrides
+	}
+	return nil
+}
+
+func (x *TestCase) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TestCase) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+// ResponseMatchers relax how the actual response for a test case is compared
+// to the expected response.
+type ResponseMatchers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If non-empty, the actual error code may be any of these codes, and the
+	// code of the expected error is ignored. This may only be used when an
+	// error is expected.
+	ErrorCodes []Code `protobuf:"varint,1,rep,packed,name=error_codes,json=errorCodes,proto3,enum=connectrpc.conformance.v1.Code" json:"error_codes,omitempty"`
+	// If non-empty, the actual error message must match this regular expression,
+	// instead of being equal to the message of the expected error. The syntax is
+	// that of RE2. The expression is not anchored, so it may match any part of
+	// the message unless it starts with "^" and ends with "$". This may only be
+	// used when an error is expected, and not with error_message_prefix.
+	ErrorMessageRegex string `protobuf:"bytes,2,opt,name=error_message_regex,json=errorMessageRegex,proto3" json:"error_message_regex,omitempty"`
+	// If non-empty, the actual error message must start with this prefix,
+	// instead of being equal to the message of the expected error. This may
+	// only be used when an error is expected, and not with error_message_regex.
+	ErrorMessagePrefix string `protobuf:"bytes,3,opt,name=error_message_prefix,json=errorMessagePrefix,proto3" json:"error_message_prefix,omitempty"`
+	// How the details of the actual error are compared to those of the
+	// expected error.
+	ErrorDetails ResponseMatchers_ErrorDetailsMatch `protobuf:"varint,4,opt,name=error_details,json=errorDetails,proto3,enum=connectrpc.conformance.v1.ResponseMatchers_ErrorDetailsMatch" json:"error_details,omitempty"`
+	// Names of response headers that must be present, with any values. If
+	// the expected response also includes one of these headers, its values
+	// are ignored.
+	ResponseHeadersPresent []string `protobuf:"bytes,5,rep,name=response_headers_present,json=responseHeadersPresent,proto3" json:"response_headers_present,omitempty"`
+	// Names of response headers that must not be present.
+	ResponseHeadersAbsent []string `protobuf:"bytes,6,rep,name=response_headers_absent,json=responseHeadersAbsent,proto3" json:"response_headers_absent,omitempty"`
+	// Names of response trailers that must be present, with any values. If
+	// the expected response also includes one of these trailers, its values
+	// are ignored.
+	ResponseTrailersPresent []string `protobuf:"bytes,7,rep,name=response_trailers_present,json=responseTrailersPresent,proto3" json:"response_trailers_present,omitempty"`
+	// Names of response trailers that must not be present.
+	ResponseTrailersAbsent []string `protobuf:"bytes,8,rep,name=response_trailers_absent,json=responseTrailersAbsent,proto3" json:"response_trailers_absent,omitempty"`
+	// The severity of a mismatched error message. If unspecified, it is the
+	// severity of the test case. When the error message is the only part of
+	// the response that does not match, the test case fails with this severity
+	// instead. This allows a test case to require the error code, but only
+	// recommend the error message, for example. This may only be used when an
+	// error is expected.
+	ErrorMessageSeverity Severity `protobuf:"varint,9,opt,name=error_message_severity,json=errorMessageSeverity,proto3,enum=connectrpc.conformance.v1.Severity" json:"error_message_severity,omitempty"`
+}
+
+func (x *ResponseMatchers) Reset() {
+	*x = ResponseMatchers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseMatchers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseMatchers) ProtoMessage() {}
+
+func (x *ResponseMatchers) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseMatchers.ProtoReflect.Descriptor instead.
+func (*ResponseMatchers) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResponseMatchers) GetErrorCodes() []Code {
+	if x != nil {
+		return x.ErrorCodes
+	}
+	return nil
+}
+
+func (x *ResponseMatchers) GetErrorMessageRegex() string {
+	if x != nil {
+		return x.ErrorMessageRegex
+	}
+	return ""
+}
+
+func (x *ResponseMatchers) GetErrorMessagePrefix() string {
+	if x != nil {
+		return x.ErrorMessagePrefix
+	}
+	return ""
+}
+
+func (x *ResponseMatchers) GetErrorDetails() ResponseMatchers_ErrorDetailsMatch {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ResponseMatchers_ERROR_DETAILS_MATCH_UNSPECIFIED
+}
+
+func (x *ResponseMatchers) GetResponseHeadersPresent() []string {
+	if x != nil {
+		return x.ResponseHeadersPresent
+	}
+	return nil
+}
+
+func (x *ResponseMatchers) GetResponseHeadersAbsent() []string {
+	if x != nil {
+		return x.ResponseHeadersAbsent
+	}
+	return nil
+}
+
+func (x *ResponseMatchers) GetResponseTrailersPresent() []string {
+	if x != nil {
+		return x.ResponseTrailersPresent
+	}
+	return nil
+}
+
+func (x *ResponseMatchers) GetResponseTrailersAbsent() []string {
+	if x != nil {
+		return x.ResponseTrailersAbsent
+	}
+	return nil
+}
+
+func (x *ResponseMatchers) GetErrorMessageSeverity() Severity {
+	if x != nil {
+		return x.ErrorMessageSeverity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
 type TestCase_ExpandedSize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -430,7 +783,7 @@ type TestCase_ExpandedSize struct {
 func (x *TestCase_ExpandedSize) Reset() {
 	*x = TestCase_ExpandedSize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -443,7 +796,7 @@ func (x *TestCase_ExpandedSize) String() string {
 func (*TestCase_ExpandedSize) ProtoMessage() {}
 
 func (x *TestCase_ExpandedSize) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,6 +819,167 @@ func (x *TestCase_ExpandedSize) GetSizeRelativeToLimit() int32 {
 	return 0
 }
 
+type TestCase_MatrixAxis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the axis, which must be a valid identifier: only letters,
+	// digits, and underscores, and it must not start with a digit.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The values of the axis. There must be at least one value, and each
+	// value must be unique.
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *TestCase_MatrixAxis) Reset() {
+	*x = TestCase_MatrixAxis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCase_MatrixAxis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase_MatrixAxis) ProtoMessage() {}
+
+func (x *TestCase_MatrixAxis) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase_MatrixAxis.ProtoReflect.Descriptor instead.
+func (*TestCase_MatrixAxis) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *TestCase_MatrixAxis) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCase_MatrixAxis) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type TestCase_ExpectedResponseOverride struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If non-empty, the override only applies to permutations that use one
+	// of these protocols.
+	Protocols []Protocol `protobuf:"varint,1,rep,packed,name=protocols,proto3,enum=connectrpc.conformance.v1.Protocol" json:"protocols,omitempty"`
+	// If non-empty, the override only applies to permutations that use one
+	// of these HTTP versions.
+	HttpVersions []HTTPVersion `protobuf:"varint,2,rep,packed,name=http_versions,json=httpVersions,proto3,enum=connectrpc.conformance.v1.HTTPVersion" json:"http_versions,omitempty"`
+	// If non-empty, the override only applies to permutations that use one
+	// of these codecs.
+	Codecs []Codec `protobuf:"varint,3,rep,packed,name=codecs,proto3,enum=connectrpc.conformance.v1.Codec" json:"codecs,omitempty"`
+	// Fields that are set in this message replace the corresponding fields
+	// of the expected response. Fields that are not set are left as is.
+	// So, for example, an override that sets only error replaces the
+	// expected error but keeps the expected headers and trailers.
+	ExpectedResponse *ClientResponseResult `protobuf:"bytes,4,opt,name=expected_response,json=expectedResponse,proto3" json:"expected_response,omitempty"`
+	// The names of fields of the expected response to clear, such as
+	// "response_trailers" or "error". This allows an override to indicate
+	// that a field should be absent, which can't be expressed by setting it.
+	// These are cleared before the fields in expected_response are applied.
+	ClearFields []string `protobuf:"bytes,5,rep,name=clear_fields,json=clearFields,proto3" json:"clear_fields,omitempty"`
+	// If present, replaces the test case's response matchers.
+	ResponseMatchers *ResponseMatchers `protobuf:"bytes,6,opt,name=response_matchers,json=responseMatchers,proto3" json:"response_matchers,omitempty"`
+}
+
+func (x *TestCase_ExpectedResponseOverride) Reset() {
+	*x = TestCase_ExpectedResponseOverride{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCase_ExpectedResponseOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase_ExpectedResponseOverride) ProtoMessage() {}
+
+func (x *TestCase_ExpectedResponseOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase_ExpectedResponseOverride.ProtoReflect.Descriptor instead.
+func (*TestCase_ExpectedResponseOverride) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetProtocols() []Protocol {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetHttpVersions() []HTTPVersion {
+	if x != nil {
+		return x.HttpVersions
+	}
+	return nil
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetCodecs() []Codec {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetExpectedResponse() *ClientResponseResult {
+	if x != nil {
+		return x.ExpectedResponse
+	}
+	return nil
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetClearFields() []string {
+	if x != nil {
+		return x.ClearFields
+	}
+	return nil
+}
+
+func (x *TestCase_ExpectedResponseOverride) GetResponseMatchers() *ResponseMatchers {
+	if x != nil {
+		return x.ResponseMatchers
+	}
+	return nil
+}
+
 var File_connectrpc_conformance_v1_suite_proto protoreflect.FileDescriptor
 
 var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
@@ -478,7 +992,7 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x26, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x08, 0x0a, 0x09, 0x54, 0x65,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xeb, 0x08, 0x0a, 0x09, 0x54, 0x65,
 	0x73, 0x74, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x6d,
 	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
@@ -531,60 +1045,171 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x1b, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x4d, 0x65, 0x73,
 	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15,
-	0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f,
-	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a,
-	0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45,
-	0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e,
-	0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
-	0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x10,
-	0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52,
-	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x47, 0x4e, 0x4f, 0x52, 0x45,
-	0x10, 0x02, 0x22, 0xf2, 0x02, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x12,
-	0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x0f, 0x65, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64,
-	0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x1a, 0x63, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69,
-	0x7a, 0x65, 0x12, 0x38, 0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74,
-	0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x48, 0x00, 0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
-	0x76, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17,
-	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74,
-	0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x8b, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24,
+	0x0a, 0x20, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f,
+	0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51,
+	0x55, 0x49, 0x52, 0x45, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
+	0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49,
+	0x47, 0x4e, 0x4f, 0x52, 0x45, 0x10, 0x02, 0x22, 0xe3, 0x09, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74,
+	0x43, 0x61, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59,
+	0x0a, 0x0f, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70,
+	0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x06, 0x6d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x4d, 0x61, 0x74,
+	0x72, 0x69, 0x78, 0x41, 0x78, 0x69, 0x73, 0x52, 0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12,
+	0x58, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x12, 0x7c, 0x0a, 0x1b, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x6f,
+	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43,
+	0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x19, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x53, 0x75, 0x69, 0x74, 0x65,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x76,
-	0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0xe2,
-	0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x63, 0x0a, 0x0c,
+	0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x38, 0x0a, 0x16,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x13,
+	0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x1a, 0x38, 0x0a, 0x0a, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x41, 0x78, 0x69, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0xbf, 0x03, 0x0a, 0x18,
+	0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x4b, 0x0a, 0x0d, 0x68,
+	0x74, 0x74, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x54, 0x54, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x68, 0x74, 0x74, 0x70,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65,
+	0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65,
+	0x63, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x58, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x52, 0x10, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x22, 0xdc, 0x05,
+	0x0a, 0x10, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x72, 0x73, 0x12, 0x40, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x67, 0x65, 0x78, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x12, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x62, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3d, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x70,
+	0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x50, 0x72, 0x65,
+	0x73, 0x65, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x41, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x19,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
+	0x73, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x17, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
+	0x73, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x62,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x41, 0x62, 0x73, 0x65,
+	0x6e, 0x74, 0x12, 0x59, 0x0a, 0x16, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x14, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x22, 0x7d, 0x0a,
+	0x11, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x23, 0x0a, 0x1f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x44, 0x45, 0x54, 0x41,
+	0x49, 0x4c, 0x53, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x55,
+	0x4e, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x5f, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x02, 0x2a, 0x4c, 0x0a, 0x08,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4d,
+	0x55, 0x53, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54,
+	0x59, 0x5f, 0x53, 0x48, 0x4f, 0x55, 0x4c, 0x44, 0x10, 0x02, 0x42, 0x8b, 0x02, 0x0a, 0x1d, 0x63,
+	0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x53, 0x75,
+	0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c,
+	0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -599,37 +1224,56 @@ func file_connectrpc_conformance_v1_suite_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_connectrpc_conformance_v1_suite_proto_goTypes = []interface{}{
-	(TestSuite_TestMode)(0),           // 0: connectrpc.conformance.v1.TestSuite.TestMode
-	(TestSuite_ConnectVersionMode)(0), // 1: connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	(*TestSuite)(nil),                 // 2: connectrpc.conformance.v1.TestSuite
-	(*TestCase)(nil),                  // 3: connectrpc.conformance.v1.TestCase
-	(*TestCase_ExpandedSize)(nil),     // 4: connectrpc.conformance.v1.TestCase.ExpandedSize
-	(Protocol)(0),                     // 5: connectrpc.conformance.v1.Protocol
-	(HTTPVersion)(0),                  // 6: connectrpc.conformance.v1.HTTPVersion
-	(Codec)(0),                        // 7: connectrpc.conformance.v1.Codec
-	(Compression)(0),                  // 8: connectrpc.conformance.v1.Compression
-	(*ClientCompatRequest)(nil),       // 9: connectrpc.conformance.v1.ClientCompatRequest
-	(*ClientResponseResult)(nil),      // 10: connectrpc.conformance.v1.ClientResponseResult
+	(Severity)(0),                             // 0: connectrpc.conformance.v1.Severity
+	(TestSuite_TestMode)(0),                   // 1: connectrpc.conformance.v1.TestSuite.TestMode
+	(TestSuite_ConnectVersionMode)(0),         // 2: connectrpc.conformance.v1.TestSuite.ConnectVersionMode
+	(ResponseMatchers_ErrorDetailsMatch)(0),   // 3: connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch
+	(*TestSuite)(nil),                         // 4: connectrpc.conformance.v1.TestSuite
+	(*TestCase)(nil),                          // 5: connectrpc.conformance.v1.TestCase
+	(*ResponseMatchers)(nil),                  // 6: connectrpc.conformance.v1.ResponseMatchers
+	(*TestCase_ExpandedSize)(nil),             // 7: connectrpc.conformance.v1.TestCase.ExpandedSize
+	(*TestCase_MatrixAxis)(nil),               // 8: connectrpc.conformance.v1.TestCase.MatrixAxis
+	(*TestCase_ExpectedResponseOverride)(nil), // 9: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride
+	(Protocol)(0),                             // 10: connectrpc.conformance.v1.Protocol
+	(HTTPVersion)(0),                          // 11: connectrpc.conformance.v1.HTTPVersion
+	(Codec)(0),                                // 12: connectrpc.conformance.v1.Codec
+	(Compression)(0),                          // 13: connectrpc.conformance.v1.Compression
+	(*ClientCompatRequest)(nil),               // 14: connectrpc.conformance.v1.ClientCompatRequest
+	(*ClientResponseResult)(nil),              // 15: connectrpc.conformance.v1.ClientResponseResult
+	(Code)(0),                                 // 16: connectrpc.conformance.v1.Code
 }
 var file_connectrpc_conformance_v1_suite_proto_depIdxs = []int32{
-	0,  // 0: connectrpc.conformance.v1.TestSuite.mode:type_name -> connectrpc.conformance.v1.TestSuite.TestMode
-	3,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
-	5,  // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
-	6,  // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
-	7,  // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
-	8,  // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
-	1,  // 6: connectrpc.conformance.v1.TestSuite.connect_version_mode:type_name -> connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	9,  // 7: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
-	4,  // 8: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
-	10, // 9: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	1,  // 0: connectrpc.conformance.v1.TestSuite.mode:type_name -> connectrpc.conformance.v1.TestSuite.TestMode
+	5,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
+	10, // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
+	11, // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
+	12, // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
+	13, // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
+	2,  // 6: connectrpc.conformance.v1.TestSuite.connect_version_mode:type_name -> connectrpc.conformance.v1.TestSuite.ConnectVersionMode
+	0,  // 7: connectrpc.conformance.v1.TestSuite.severity:type_name -> connectrpc.conformance.v1.Severity
+	14, // 8: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
+	7,  // 9: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
+	15, // 10: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
+	8,  // 11: connectrpc.conformance.v1.TestCase.matrix:type_name -> connectrpc.conformance.v1.TestCase.MatrixAxis
+	6,  // 12: connectrpc.conformance.v1.TestCase.response_matchers:type_name -> connectrpc.conformance.v1.ResponseMatchers
+	9,  // 13: connectrpc.conformance.v1.TestCase.expected_response_overrides:type_name -> connectrpc.conformance.v1.TestCase.ExpectedResponseOverride
+	0,  // 14: connectrpc.conformance.v1.TestCase.severity:type_name -> connectrpc.conformance.v1.Severity
+	16, // 15: connectrpc.conformance.v1.ResponseMatchers.error_codes:type_name -> connectrpc.conformance.v1.Code
+	3,  // 16: connectrpc.conformance.v1.ResponseMatchers.error_details:type_name -> connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch
+	0,  // 17: connectrpc.conformance.v1.ResponseMatchers.error_message_severity:type_name -> connectrpc.conformance.v1.Severity
+	10, // 18: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.protocols:type_name -> connectrpc.conformance.v1.Protocol
+	11, // 19: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
+	12, // 20: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.codecs:type_name -> connectrpc.conformance.v1.Codec
+	15, // 21: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
+	6,  // 22: connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.response_matchers:type_name -> connectrpc.conformance.v1.ResponseMatchers
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_suite_proto_init() }
@@ -665,6 +1309,18 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_suite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseMatchers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_suite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestCase_ExpandedSize); i {
 			case 0:
 				return &v.state
@@ -676,15 +1332,39 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 				return nil
 			}
 		}
+		file_connectrpc_conformance_v1_suite_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestCase_MatrixAxis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_suite_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestCase_ExpectedResponseOverride); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_connectrpc_conformance_v1_suite_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_suite_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_suite_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -285,6 +285,11 @@ export class ClientResponseResult extends jspb.Message {
   clearFeedbackList(): ClientResponseResult;
   addFeedback(value: string, index?: number): ClientResponseResult;
 
+  getRecommendationFeedbackList(): Array<string>;
+  setRecommendationFeedbackList(value: Array<string>): ClientResponseResult;
+  clearRecommendationFeedbackList(): ClientResponseResult;
+  addRecommendationFeedback(value: string, index?: number): ClientResponseResult;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): ClientResponseResult.AsObject;
   static toObject(includeInstance: boolean, msg: ClientResponseResult): ClientResponseResult.AsObject;
@@ -302,6 +307,7 @@ export namespace ClientResponseResult {
     numUnsentRequests: number,
     httpStatusCode?: number,
     feedbackList: Array<string>,
+    recommendationFeedbackList: Array<string>,
   }
 
   export enum HttpStatusCodeCase { 
@@ -1841,7 +1841,7 @@ proto.connectrpc.conformance.v1.ClientCompatResponse.prototype.hasError = functi
  * @private {!Array<number>}
  * @const
  */
-proto.connectrpc.conformance.v1.ClientResponseResult.repeatedFields_ = [1,2,4,7];
+proto.connectrpc.conformance.v1.ClientResponseResult.repeatedFields_ = [1,2,4,7,8];
 
 
 
@@ -1883,7 +1883,8 @@ proto.connectrpc.conformance.v1.ClientResponseResult.toObject = function(include
     connectrpc_conformance_v1_service_pb.Header.toObject, includeInstance),
     numUnsentRequests: jspb.Message.getFieldWithDefault(msg, 5, 0),
     httpStatusCode: jspb.Message.getFieldWithDefault(msg, 6, 0),
-    feedbackList: (f = jspb.Message.getRepeatedField(msg, 7)) == null ? undefined : f
+    feedbackList: (f = jspb.Message.getRepeatedField(msg, 7)) == null ? undefined : f,
+    recommendationFeedbackList: (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f
   };
 
   if (includeInstance) {
@@ -1952,6 +1953,10 @@ proto.connectrpc.conformance.v1.ClientResponseResult.deserializeBinaryFromReader
       var value = /** @type {string} */ (reader.readString());
       msg.addFeedback(value);
       break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addRecommendationFeedback(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -2034,6 +2039,13 @@ proto.connectrpc.conformance.v1.ClientResponseResult.serializeBinaryToWriter = f
       f
     );
   }
+  f = message.getRecommendationFeedbackList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
 };
 
 
@@ -2279,6 +2291,43 @@ proto.connectrpc.conformance.v1.ClientResponseResult.prototype.clearFeedbackList
 };
 
 
+/**
+ * repeated string recommendation_feedback = 8;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.ClientResponseResult.prototype.getRecommendationFeedbackList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.ClientResponseResult} returns this
+ */
+proto.connectrpc.conformance.v1.ClientResponseResult.prototype.setRecommendationFeedbackList = function(value) {
+  return jspb.Message.setField(this, 8, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ClientResponseResult} returns this
+ */
+proto.connectrpc.conformance.v1.ClientResponseResult.prototype.addRecommendationFeedback = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ClientResponseResult} returns this
+ */
+proto.connectrpc.conformance.v1.ClientResponseResult.prototype.clearRecommendationFeedbackList = function() {
+  return this.setRecommendationFeedbackList([]);
+};
+
+
 
 
 
@@ -32,6 +32,9 @@ export class Config extends jspb.Message {
   clearExcludeCasesList(): Config;
   addExcludeCases(value?: ConfigCase, index?: number): ConfigCase;
 
+  getStdioFormat(): StdioFormat;
+  setStdioFormat(value: StdioFormat): Config;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): Config.AsObject;
   static toObject(includeInstance: boolean, msg: Config): Config.AsObject;
@@ -45,6 +48,7 @@ export namespace Config {
     features?: Features.AsObject,
     includeCasesList: Array<ConfigCase.AsObject>,
     excludeCasesList: Array<ConfigCase.AsObject>,
+    stdioFormat: StdioFormat,
   }
 }
 
@@ -236,6 +240,11 @@ export namespace ConfigCase {
   }
 }
 
+export enum StdioFormat { 
+  STDIO_FORMAT_UNSPECIFIED = 0,
+  STDIO_FORMAT_BINARY = 1,
+  STDIO_FORMAT_JSON = 2,
+}
 export enum HTTPVersion { 
   HTTP_VERSION_UNSPECIFIED = 0,
   HTTP_VERSION_1 = 1,
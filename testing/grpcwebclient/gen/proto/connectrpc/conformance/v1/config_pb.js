@@ -43,6 +43,7 @@ goog.exportSymbol('proto.connectrpc.conformance.v1.ConfigCase', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.Features', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.HTTPVersion', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.Protocol', null, global);
+goog.exportSymbol('proto.connectrpc.conformance.v1.StdioFormat', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.StreamType', null, global);
 /**
  * Generated by JsPbCodeGenerator.
@@ -150,7 +151,8 @@ proto.connectrpc.conformance.v1.Config.toObject = function(includeInstance, msg)
     includeCasesList: jspb.Message.toObjectList(msg.getIncludeCasesList(),
     proto.connectrpc.conformance.v1.ConfigCase.toObject, includeInstance),
     excludeCasesList: jspb.Message.toObjectList(msg.getExcludeCasesList(),
-    proto.connectrpc.conformance.v1.ConfigCase.toObject, includeInstance)
+    proto.connectrpc.conformance.v1.ConfigCase.toObject, includeInstance),
+    stdioFormat: jspb.Message.getFieldWithDefault(msg, 4, 0)
   };
 
   if (includeInstance) {
@@ -202,6 +204,10 @@ proto.connectrpc.conformance.v1.Config.deserializeBinaryFromReader = function(ms
       reader.readMessage(value,proto.connectrpc.conformance.v1.ConfigCase.deserializeBinaryFromReader);
       msg.addExcludeCases(value);
       break;
+    case 4:
+      var value = /** @type {!proto.connectrpc.conformance.v1.StdioFormat} */ (reader.readEnum());
+      msg.setStdioFormat(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -255,6 +261,13 @@ proto.connectrpc.conformance.v1.Config.serializeBinaryToWriter = function(messag
       proto.connectrpc.conformance.v1.ConfigCase.serializeBinaryToWriter
     );
   }
+  f = message.getStdioFormat();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -371,6 +384,24 @@ proto.connectrpc.conformance.v1.Config.prototype.clearExcludeCasesList = functio
 };
 
 
+/**
+ * optional StdioFormat stdio_format = 4;
+ * @return {!proto.connectrpc.conformance.v1.StdioFormat}
+ */
+proto.connectrpc.conformance.v1.Config.prototype.getStdioFormat = function() {
+  return /** @type {!proto.connectrpc.conformance.v1.StdioFormat} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.StdioFormat} value
+ * @return {!proto.connectrpc.conformance.v1.Config} returns this
+ */
+proto.connectrpc.conformance.v1.Config.prototype.setStdioFormat = function(value) {
+  return jspb.Message.setProto3EnumField(this, 4, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -1463,6 +1494,15 @@ proto.connectrpc.conformance.v1.ConfigCase.prototype.hasUseMessageReceiveLimit =
 };
 
 
+/**
+ * @enum {number}
+ */
+proto.connectrpc.conformance.v1.StdioFormat = {
+  STDIO_FORMAT_UNSPECIFIED: 0,
+  STDIO_FORMAT_BINARY: 1,
+  STDIO_FORMAT_JSON: 2
+};
+
 /**
  * @enum {number}
  */
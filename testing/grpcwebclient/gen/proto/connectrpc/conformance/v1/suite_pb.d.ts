@@ -65,6 +65,14 @@ export class TestSuite extends jspb.Message {
   getReliesOnMessageReceiveLimit(): boolean;
   setReliesOnMessageReceiveLimit(value: boolean): TestSuite;
 
+  getTagsList(): Array<string>;
+  setTagsList(value: Array<string>): TestSuite;
+  clearTagsList(): TestSuite;
+  addTags(value: string, index?: number): TestSuite;
+
+  getSeverity(): Severity;
+  setSeverity(value: Severity): TestSuite;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): TestSuite.AsObject;
   static toObject(includeInstance: boolean, msg: TestSuite): TestSuite.AsObject;
@@ -87,6 +95,8 @@ export namespace TestSuite {
     reliesOnTlsClientCerts: boolean,
     reliesOnConnectGet: boolean,
     reliesOnMessageReceiveLimit: boolean,
+    tagsList: Array<string>,
+    severity: Severity,
   }
 
   export enum TestMode { 
@@ -118,6 +128,29 @@ export class TestCase extends jspb.Message {
   hasExpectedResponse(): boolean;
   clearExpectedResponse(): TestCase;
 
+  getMatrixList(): Array<TestCase.MatrixAxis>;
+  setMatrixList(value: Array<TestCase.MatrixAxis>): TestCase;
+  clearMatrixList(): TestCase;
+  addMatrix(value?: TestCase.MatrixAxis, index?: number): TestCase.MatrixAxis;
+
+  getResponseMatchers(): ResponseMatchers | undefined;
+  setResponseMatchers(value?: ResponseMatchers): TestCase;
+  hasResponseMatchers(): boolean;
+  clearResponseMatchers(): TestCase;
+
+  getExpectedResponseOverridesList(): Array<TestCase.ExpectedResponseOverride>;
+  setExpectedResponseOverridesList(value: Array<TestCase.ExpectedResponseOverride>): TestCase;
+  clearExpectedResponseOverridesList(): TestCase;
+  addExpectedResponseOverrides(value?: TestCase.ExpectedResponseOverride, index?: number): TestCase.ExpectedResponseOverride;
+
+  getTagsList(): Array<string>;
+  setTagsList(value: Array<string>): TestCase;
+  clearTagsList(): TestCase;
+  addTags(value: string, index?: number): TestCase;
+
+  getSeverity(): Severity;
+  setSeverity(value: Severity): TestCase;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): TestCase.AsObject;
   static toObject(includeInstance: boolean, msg: TestCase): TestCase.AsObject;
@@ -131,6 +164,11 @@ export namespace TestCase {
     request?: connectrpc_conformance_v1_client_compat_pb.ClientCompatRequest.AsObject,
     expandRequestsList: Array<TestCase.ExpandedSize.AsObject>,
     expectedResponse?: connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.AsObject,
+    matrixList: Array<TestCase.MatrixAxis.AsObject>,
+    responseMatchers?: ResponseMatchers.AsObject,
+    expectedResponseOverridesList: Array<TestCase.ExpectedResponseOverride.AsObject>,
+    tagsList: Array<string>,
+    severity: Severity,
   }
 
   export class ExpandedSize extends jspb.Message {
@@ -158,5 +196,152 @@ export namespace TestCase {
     }
   }
 
+
+  export class MatrixAxis extends jspb.Message {
+    getName(): string;
+    setName(value: string): MatrixAxis;
+
+    getValuesList(): Array<string>;
+    setValuesList(value: Array<string>): MatrixAxis;
+    clearValuesList(): MatrixAxis;
+    addValues(value: string, index?: number): MatrixAxis;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): MatrixAxis.AsObject;
+    static toObject(includeInstance: boolean, msg: MatrixAxis): MatrixAxis.AsObject;
+    static serializeBinaryToWriter(message: MatrixAxis, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): MatrixAxis;
+    static deserializeBinaryFromReader(message: MatrixAxis, reader: jspb.BinaryReader): MatrixAxis;
+  }
+
+  export namespace MatrixAxis {
+    export type AsObject = {
+      name: string,
+      valuesList: Array<string>,
+    }
+  }
+
+
+  export class ExpectedResponseOverride extends jspb.Message {
+    getProtocolsList(): Array<connectrpc_conformance_v1_config_pb.Protocol>;
+    setProtocolsList(value: Array<connectrpc_conformance_v1_config_pb.Protocol>): ExpectedResponseOverride;
+    clearProtocolsList(): ExpectedResponseOverride;
+    addProtocols(value: connectrpc_conformance_v1_config_pb.Protocol, index?: number): ExpectedResponseOverride;
+
+    getHttpVersionsList(): Array<connectrpc_conformance_v1_config_pb.HTTPVersion>;
+    setHttpVersionsList(value: Array<connectrpc_conformance_v1_config_pb.HTTPVersion>): ExpectedResponseOverride;
+    clearHttpVersionsList(): ExpectedResponseOverride;
+    addHttpVersions(value: connectrpc_conformance_v1_config_pb.HTTPVersion, index?: number): ExpectedResponseOverride;
+
+    getCodecsList(): Array<connectrpc_conformance_v1_config_pb.Codec>;
+    setCodecsList(value: Array<connectrpc_conformance_v1_config_pb.Codec>): ExpectedResponseOverride;
+    clearCodecsList(): ExpectedResponseOverride;
+    addCodecs(value: connectrpc_conformance_v1_config_pb.Codec, index?: number): ExpectedResponseOverride;
+
+    getExpectedResponse(): connectrpc_conformance_v1_client_compat_pb.ClientResponseResult | undefined;
+    setExpectedResponse(value?: connectrpc_conformance_v1_client_compat_pb.ClientResponseResult): ExpectedResponseOverride;
+    hasExpectedResponse(): boolean;
+    clearExpectedResponse(): ExpectedResponseOverride;
+
+    getClearFieldsList(): Array<string>;
+    setClearFieldsList(value: Array<string>): ExpectedResponseOverride;
+    clearClearFieldsList(): ExpectedResponseOverride;
+    addClearFields(value: string, index?: number): ExpectedResponseOverride;
+
+    getResponseMatchers(): ResponseMatchers | undefined;
+    setResponseMatchers(value?: ResponseMatchers): ExpectedResponseOverride;
+    hasResponseMatchers(): boolean;
+    clearResponseMatchers(): ExpectedResponseOverride;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ExpectedResponseOverride.AsObject;
+    static toObject(includeInstance: boolean, msg: ExpectedResponseOverride): ExpectedResponseOverride.AsObject;
+    static serializeBinaryToWriter(message: ExpectedResponseOverride, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ExpectedResponseOverride;
+    static deserializeBinaryFromReader(message: ExpectedResponseOverride, reader: jspb.BinaryReader): ExpectedResponseOverride;
+  }
+
+  export namespace ExpectedResponseOverride {
+    export type AsObject = {
+      protocolsList: Array<connectrpc_conformance_v1_config_pb.Protocol>,
+      httpVersionsList: Array<connectrpc_conformance_v1_config_pb.HTTPVersion>,
+      codecsList: Array<connectrpc_conformance_v1_config_pb.Codec>,
+      expectedResponse?: connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.AsObject,
+      clearFieldsList: Array<string>,
+      responseMatchers?: ResponseMatchers.AsObject,
+    }
+  }
+
 }
 
+export class ResponseMatchers extends jspb.Message {
+  getErrorCodesList(): Array<connectrpc_conformance_v1_config_pb.Code>;
+  setErrorCodesList(value: Array<connectrpc_conformance_v1_config_pb.Code>): ResponseMatchers;
+  clearErrorCodesList(): ResponseMatchers;
+  addErrorCodes(value: connectrpc_conformance_v1_config_pb.Code, index?: number): ResponseMatchers;
+
+  getErrorMessageRegex(): string;
+  setErrorMessageRegex(value: string): ResponseMatchers;
+
+  getErrorMessagePrefix(): string;
+  setErrorMessagePrefix(value: string): ResponseMatchers;
+
+  getErrorDetails(): ResponseMatchers.ErrorDetailsMatch;
+  setErrorDetails(value: ResponseMatchers.ErrorDetailsMatch): ResponseMatchers;
+
+  getResponseHeadersPresentList(): Array<string>;
+  setResponseHeadersPresentList(value: Array<string>): ResponseMatchers;
+  clearResponseHeadersPresentList(): ResponseMatchers;
+  addResponseHeadersPresent(value: string, index?: number): ResponseMatchers;
+
+  getResponseHeadersAbsentList(): Array<string>;
+  setResponseHeadersAbsentList(value: Array<string>): ResponseMatchers;
+  clearResponseHeadersAbsentList(): ResponseMatchers;
+  addResponseHeadersAbsent(value: string, index?: number): ResponseMatchers;
+
+  getResponseTrailersPresentList(): Array<string>;
+  setResponseTrailersPresentList(value: Array<string>): ResponseMatchers;
+  clearResponseTrailersPresentList(): ResponseMatchers;
+  addResponseTrailersPresent(value: string, index?: number): ResponseMatchers;
+
+  getResponseTrailersAbsentList(): Array<string>;
+  setResponseTrailersAbsentList(value: Array<string>): ResponseMatchers;
+  clearResponseTrailersAbsentList(): ResponseMatchers;
+  addResponseTrailersAbsent(value: string, index?: number): ResponseMatchers;
+
+  getErrorMessageSeverity(): Severity;
+  setErrorMessageSeverity(value: Severity): ResponseMatchers;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResponseMatchers.AsObject;
+  static toObject(includeInstance: boolean, msg: ResponseMatchers): ResponseMatchers.AsObject;
+  static serializeBinaryToWriter(message: ResponseMatchers, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResponseMatchers;
+  static deserializeBinaryFromReader(message: ResponseMatchers, reader: jspb.BinaryReader): ResponseMatchers;
+}
+
+export namespace ResponseMatchers {
+  export type AsObject = {
+    errorCodesList: Array<connectrpc_conformance_v1_config_pb.Code>,
+    errorMessageRegex: string,
+    errorMessagePrefix: string,
+    errorDetails: ResponseMatchers.ErrorDetailsMatch,
+    responseHeadersPresentList: Array<string>,
+    responseHeadersAbsentList: Array<string>,
+    responseTrailersPresentList: Array<string>,
+    responseTrailersAbsentList: Array<string>,
+    errorMessageSeverity: Severity,
+  }
+
+  export enum ErrorDetailsMatch { 
+    ERROR_DETAILS_MATCH_UNSPECIFIED = 0,
+    ERROR_DETAILS_MATCH_UNORDERED = 1,
+    ERROR_DETAILS_MATCH_CONTAINS = 2,
+  }
+}
+
+export enum Severity { 
+  SEVERITY_UNSPECIFIED = 0,
+  SEVERITY_MUST = 1,
+  SEVERITY_SHOULD = 2,
+}
@@ -39,8 +39,13 @@ var connectrpc_conformance_v1_client_compat_pb = require('../../../connectrpc/co
 goog.object.extend(proto, connectrpc_conformance_v1_client_compat_pb);
 var connectrpc_conformance_v1_config_pb = require('../../../connectrpc/conformance/v1/config_pb.js');
 goog.object.extend(proto, connectrpc_conformance_v1_config_pb);
+goog.exportSymbol('proto.connectrpc.conformance.v1.ResponseMatchers', null, global);
+goog.exportSymbol('proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch', null, global);
+goog.exportSymbol('proto.connectrpc.conformance.v1.Severity', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.TestCase', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.TestCase.ExpandedSize', null, global);
+goog.exportSymbol('proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride', null, global);
+goog.exportSymbol('proto.connectrpc.conformance.v1.TestCase.MatrixAxis', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.TestSuite', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.TestSuite.ConnectVersionMode', null, global);
 goog.exportSymbol('proto.connectrpc.conformance.v1.TestSuite.TestMode', null, global);
@@ -107,13 +112,76 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.connectrpc.conformance.v1.TestCase.ExpandedSize.displayName = 'proto.connectrpc.conformance.v1.TestCase.ExpandedSize';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.connectrpc.conformance.v1.TestCase.MatrixAxis.repeatedFields_, null);
+};
+goog.inherits(proto.connectrpc.conformance.v1.TestCase.MatrixAxis, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.connectrpc.conformance.v1.TestCase.MatrixAxis.displayName = 'proto.connectrpc.conformance.v1.TestCase.MatrixAxis';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.repeatedFields_, null);
+};
+goog.inherits(proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.displayName = 'proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.connectrpc.conformance.v1.ResponseMatchers.repeatedFields_, null);
+};
+goog.inherits(proto.connectrpc.conformance.v1.ResponseMatchers, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.connectrpc.conformance.v1.ResponseMatchers.displayName = 'proto.connectrpc.conformance.v1.ResponseMatchers';
+}
 
 /**
  * List of repeated fields within this message type.
  * @private {!Array<number>}
  * @const
  */
-proto.connectrpc.conformance.v1.TestSuite.repeatedFields_ = [3,4,5,6,7];
+proto.connectrpc.conformance.v1.TestSuite.repeatedFields_ = [3,4,5,6,7,13];
 
 
 
@@ -158,7 +226,9 @@ proto.connectrpc.conformance.v1.TestSuite.toObject = function(includeInstance, m
     reliesOnTls: jspb.Message.getBooleanFieldWithDefault(msg, 9, false),
     reliesOnTlsClientCerts: jspb.Message.getBooleanFieldWithDefault(msg, 10, false),
     reliesOnConnectGet: jspb.Message.getBooleanFieldWithDefault(msg, 11, false),
-    reliesOnMessageReceiveLimit: jspb.Message.getBooleanFieldWithDefault(msg, 12, false)
+    reliesOnMessageReceiveLimit: jspb.Message.getBooleanFieldWithDefault(msg, 12, false),
+    tagsList: (f = jspb.Message.getRepeatedField(msg, 13)) == null ? undefined : f,
+    severity: jspb.Message.getFieldWithDefault(msg, 14, 0)
   };
 
   if (includeInstance) {
@@ -252,6 +322,14 @@ proto.connectrpc.conformance.v1.TestSuite.deserializeBinaryFromReader = function
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setReliesOnMessageReceiveLimit(value);
       break;
+    case 13:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addTags(value);
+      break;
+    case 14:
+      var value = /** @type {!proto.connectrpc.conformance.v1.Severity} */ (reader.readEnum());
+      msg.setSeverity(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -366,6 +444,20 @@ proto.connectrpc.conformance.v1.TestSuite.serializeBinaryToWriter = function(mes
       f
     );
   }
+  f = message.getTagsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      13,
+      f
+    );
+  }
+  f = message.getSeverity();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      14,
+      f
+    );
+  }
 };
 
 
@@ -699,13 +791,68 @@ proto.connectrpc.conformance.v1.TestSuite.prototype.setReliesOnMessageReceiveLim
 };
 
 
+/**
+ * repeated string tags = 13;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.getTagsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 13));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.TestSuite} returns this
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.setTagsList = function(value) {
+  return jspb.Message.setField(this, 13, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestSuite} returns this
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.addTags = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 13, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestSuite} returns this
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.clearTagsList = function() {
+  return this.setTagsList([]);
+};
+
+
+/**
+ * optional Severity severity = 14;
+ * @return {!proto.connectrpc.conformance.v1.Severity}
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.getSeverity = function() {
+  return /** @type {!proto.connectrpc.conformance.v1.Severity} */ (jspb.Message.getFieldWithDefault(this, 14, 0));
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Severity} value
+ * @return {!proto.connectrpc.conformance.v1.TestSuite} returns this
+ */
+proto.connectrpc.conformance.v1.TestSuite.prototype.setSeverity = function(value) {
+  return jspb.Message.setProto3EnumField(this, 14, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
  * @private {!Array<number>}
  * @const
  */
-proto.connectrpc.conformance.v1.TestCase.repeatedFields_ = [2];
+proto.connectrpc.conformance.v1.TestCase.repeatedFields_ = [2,4,6,7];
 
 
 
@@ -741,7 +888,14 @@ proto.connectrpc.conformance.v1.TestCase.toObject = function(includeInstance, ms
     request: (f = msg.getRequest()) && connectrpc_conformance_v1_client_compat_pb.ClientCompatRequest.toObject(includeInstance, f),
     expandRequestsList: jspb.Message.toObjectList(msg.getExpandRequestsList(),
     proto.connectrpc.conformance.v1.TestCase.ExpandedSize.toObject, includeInstance),
-    expectedResponse: (f = msg.getExpectedResponse()) && connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.toObject(includeInstance, f)
+    expectedResponse: (f = msg.getExpectedResponse()) && connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.toObject(includeInstance, f),
+    matrixList: jspb.Message.toObjectList(msg.getMatrixList(),
+    proto.connectrpc.conformance.v1.TestCase.MatrixAxis.toObject, includeInstance),
+    responseMatchers: (f = msg.getResponseMatchers()) && proto.connectrpc.conformance.v1.ResponseMatchers.toObject(includeInstance, f),
+    expectedResponseOverridesList: jspb.Message.toObjectList(msg.getExpectedResponseOverridesList(),
+    proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.toObject, includeInstance),
+    tagsList: (f = jspb.Message.getRepeatedField(msg, 7)) == null ? undefined : f,
+    severity: jspb.Message.getFieldWithDefault(msg, 8, 0)
   };
 
   if (includeInstance) {
@@ -793,6 +947,29 @@ proto.connectrpc.conformance.v1.TestCase.deserializeBinaryFromReader = function(
       reader.readMessage(value,connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.deserializeBinaryFromReader);
       msg.setExpectedResponse(value);
       break;
+    case 4:
+      var value = new proto.connectrpc.conformance.v1.TestCase.MatrixAxis;
+      reader.readMessage(value,proto.connectrpc.conformance.v1.TestCase.MatrixAxis.deserializeBinaryFromReader);
+      msg.addMatrix(value);
+      break;
+    case 5:
+      var value = new proto.connectrpc.conformance.v1.ResponseMatchers;
+      reader.readMessage(value,proto.connectrpc.conformance.v1.ResponseMatchers.deserializeBinaryFromReader);
+      msg.setResponseMatchers(value);
+      break;
+    case 6:
+      var value = new proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride;
+      reader.readMessage(value,proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.deserializeBinaryFromReader);
+      msg.addExpectedResponseOverrides(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addTags(value);
+      break;
+    case 8:
+      var value = /** @type {!proto.connectrpc.conformance.v1.Severity} */ (reader.readEnum());
+      msg.setSeverity(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -846,6 +1023,44 @@ proto.connectrpc.conformance.v1.TestCase.serializeBinaryToWriter = function(mess
       connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.serializeBinaryToWriter
     );
   }
+  f = message.getMatrixList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      4,
+      f,
+      proto.connectrpc.conformance.v1.TestCase.MatrixAxis.serializeBinaryToWriter
+    );
+  }
+  f = message.getResponseMatchers();
+  if (f != null) {
+    writer.writeMessage(
+      5,
+      f,
+      proto.connectrpc.conformance.v1.ResponseMatchers.serializeBinaryToWriter
+    );
+  }
+  f = message.getExpectedResponseOverridesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      6,
+      f,
+      proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.serializeBinaryToWriter
+    );
+  }
+  f = message.getTagsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      7,
+      f
+    );
+  }
+  f = message.getSeverity();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      8,
+      f
+    );
+  }
 };
 
 
@@ -997,116 +1212,1373 @@ proto.connectrpc.conformance.v1.TestCase.ExpandedSize.prototype.hasSizeRelativeT
 };
 
 
+
 /**
- * optional ClientCompatRequest request = 1;
- * @return {?proto.connectrpc.conformance.v1.ClientCompatRequest}
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.getRequest = function() {
-  return /** @type{?proto.connectrpc.conformance.v1.ClientCompatRequest} */ (
-    jspb.Message.getWrapperField(this, connectrpc_conformance_v1_client_compat_pb.ClientCompatRequest, 1));
-};
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.repeatedFields_ = [2];
 
 
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
 /**
- * @param {?proto.connectrpc.conformance.v1.ClientCompatRequest|undefined} value
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
-*/
-proto.connectrpc.conformance.v1.TestCase.prototype.setRequest = function(value) {
-  return jspb.Message.setWrapperField(this, 1, value);
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.toObject = function(opt_includeInstance) {
+  return proto.connectrpc.conformance.v1.TestCase.MatrixAxis.toObject(opt_includeInstance, this);
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.clearRequest = function() {
-  return this.setRequest(undefined);
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    name: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    valuesList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
 };
+}
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis}
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.hasRequest = function() {
-  return jspb.Message.getField(this, 1) != null;
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.connectrpc.conformance.v1.TestCase.MatrixAxis;
+  return proto.connectrpc.conformance.v1.TestCase.MatrixAxis.deserializeBinaryFromReader(msg, reader);
 };
 
 
 /**
- * repeated ExpandedSize expand_requests = 2;
- * @return {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>}
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis}
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.getExpandRequestsList = function() {
-  return /** @type{!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>} */ (
-    jspb.Message.getRepeatedWrapperField(this, proto.connectrpc.conformance.v1.TestCase.ExpandedSize, 2));
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setName(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addValues(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
 };
 
 
 /**
- * @param {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>} value
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
-*/
-proto.connectrpc.conformance.v1.TestCase.prototype.setExpandRequestsList = function(value) {
-  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.connectrpc.conformance.v1.TestCase.MatrixAxis.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
 /**
- * @param {!proto.connectrpc.conformance.v1.TestCase.ExpandedSize=} opt_value
- * @param {number=} opt_index
- * @return {!proto.connectrpc.conformance.v1.TestCase.ExpandedSize}
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.addExpandRequests = function(opt_value, opt_index) {
-  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.connectrpc.conformance.v1.TestCase.ExpandedSize, opt_index);
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getName();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getValuesList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      2,
+      f
+    );
+  }
 };
 
 
 /**
- * Clears the list making it empty but non-null.
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
- */
+ * optional string name = 1;
+ * @return {string}
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.getName = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.setName = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * repeated string values = 2;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.getValuesList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.setValuesList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.addValues = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.MatrixAxis.prototype.clearValuesList = function() {
+  return this.setValuesList([]);
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.repeatedFields_ = [1,2,3,5];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.toObject = function(opt_includeInstance) {
+  return proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    protocolsList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    httpVersionsList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f,
+    codecsList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
+    expectedResponse: (f = msg.getExpectedResponse()) && connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.toObject(includeInstance, f),
+    clearFieldsList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
+    responseMatchers: (f = msg.getResponseMatchers()) && proto.connectrpc.conformance.v1.ResponseMatchers.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride;
+  return proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var values = /** @type {!Array<!proto.connectrpc.conformance.v1.Protocol>} */ (reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]);
+      for (var i = 0; i < values.length; i++) {
+        msg.addProtocols(values[i]);
+      }
+      break;
+    case 2:
+      var values = /** @type {!Array<!proto.connectrpc.conformance.v1.HTTPVersion>} */ (reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]);
+      for (var i = 0; i < values.length; i++) {
+        msg.addHttpVersions(values[i]);
+      }
+      break;
+    case 3:
+      var values = /** @type {!Array<!proto.connectrpc.conformance.v1.Codec>} */ (reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]);
+      for (var i = 0; i < values.length; i++) {
+        msg.addCodecs(values[i]);
+      }
+      break;
+    case 4:
+      var value = new connectrpc_conformance_v1_client_compat_pb.ClientResponseResult;
+      reader.readMessage(value,connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.deserializeBinaryFromReader);
+      msg.setExpectedResponse(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addClearFields(value);
+      break;
+    case 6:
+      var value = new proto.connectrpc.conformance.v1.ResponseMatchers;
+      reader.readMessage(value,proto.connectrpc.conformance.v1.ResponseMatchers.deserializeBinaryFromReader);
+      msg.setResponseMatchers(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getProtocolsList();
+  if (f.length > 0) {
+    writer.writePackedEnum(
+      1,
+      f
+    );
+  }
+  f = message.getHttpVersionsList();
+  if (f.length > 0) {
+    writer.writePackedEnum(
+      2,
+      f
+    );
+  }
+  f = message.getCodecsList();
+  if (f.length > 0) {
+    writer.writePackedEnum(
+      3,
+      f
+    );
+  }
+  f = message.getExpectedResponse();
+  if (f != null) {
+    writer.writeMessage(
+      4,
+      f,
+      connectrpc_conformance_v1_client_compat_pb.ClientResponseResult.serializeBinaryToWriter
+    );
+  }
+  f = message.getClearFieldsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getResponseMatchers();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      proto.connectrpc.conformance.v1.ResponseMatchers.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated Protocol protocols = 1;
+ * @return {!Array<!proto.connectrpc.conformance.v1.Protocol>}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getProtocolsList = function() {
+  return /** @type {!Array<!proto.connectrpc.conformance.v1.Protocol>} */ (jspb.Message.getRepeatedField(this, 1));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.Protocol>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setProtocolsList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Protocol} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.addProtocols = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearProtocolsList = function() {
+  return this.setProtocolsList([]);
+};
+
+
+/**
+ * repeated HTTPVersion http_versions = 2;
+ * @return {!Array<!proto.connectrpc.conformance.v1.HTTPVersion>}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getHttpVersionsList = function() {
+  return /** @type {!Array<!proto.connectrpc.conformance.v1.HTTPVersion>} */ (jspb.Message.getRepeatedField(this, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.HTTPVersion>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setHttpVersionsList = function(value) {
+  return jspb.Message.setField(this, 2, value || []);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.HTTPVersion} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.addHttpVersions = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 2, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearHttpVersionsList = function() {
+  return this.setHttpVersionsList([]);
+};
+
+
+/**
+ * repeated Codec codecs = 3;
+ * @return {!Array<!proto.connectrpc.conformance.v1.Codec>}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getCodecsList = function() {
+  return /** @type {!Array<!proto.connectrpc.conformance.v1.Codec>} */ (jspb.Message.getRepeatedField(this, 3));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.Codec>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setCodecsList = function(value) {
+  return jspb.Message.setField(this, 3, value || []);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Codec} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.addCodecs = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearCodecsList = function() {
+  return this.setCodecsList([]);
+};
+
+
+/**
+ * optional ClientResponseResult expected_response = 4;
+ * @return {?proto.connectrpc.conformance.v1.ClientResponseResult}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getExpectedResponse = function() {
+  return /** @type{?proto.connectrpc.conformance.v1.ClientResponseResult} */ (
+    jspb.Message.getWrapperField(this, connectrpc_conformance_v1_client_compat_pb.ClientResponseResult, 4));
+};
+
+
+/**
+ * @param {?proto.connectrpc.conformance.v1.ClientResponseResult|undefined} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setExpectedResponse = function(value) {
+  return jspb.Message.setWrapperField(this, 4, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearExpectedResponse = function() {
+  return this.setExpectedResponse(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.hasExpectedResponse = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
+/**
+ * repeated string clear_fields = 5;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getClearFieldsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setClearFieldsList = function(value) {
+  return jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.addClearFields = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearClearFieldsList = function() {
+  return this.setClearFieldsList([]);
+};
+
+
+/**
+ * optional ResponseMatchers response_matchers = 6;
+ * @return {?proto.connectrpc.conformance.v1.ResponseMatchers}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.getResponseMatchers = function() {
+  return /** @type{?proto.connectrpc.conformance.v1.ResponseMatchers} */ (
+    jspb.Message.getWrapperField(this, proto.connectrpc.conformance.v1.ResponseMatchers, 6));
+};
+
+
+/**
+ * @param {?proto.connectrpc.conformance.v1.ResponseMatchers|undefined} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.setResponseMatchers = function(value) {
+  return jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.clearResponseMatchers = function() {
+  return this.setResponseMatchers(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride.prototype.hasResponseMatchers = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+/**
+ * optional ClientCompatRequest request = 1;
+ * @return {?proto.connectrpc.conformance.v1.ClientCompatRequest}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getRequest = function() {
+  return /** @type{?proto.connectrpc.conformance.v1.ClientCompatRequest} */ (
+    jspb.Message.getWrapperField(this, connectrpc_conformance_v1_client_compat_pb.ClientCompatRequest, 1));
+};
+
+
+/**
+ * @param {?proto.connectrpc.conformance.v1.ClientCompatRequest|undefined} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setRequest = function(value) {
+  return jspb.Message.setWrapperField(this, 1, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearRequest = function() {
+  return this.setRequest(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.hasRequest = function() {
+  return jspb.Message.getField(this, 1) != null;
+};
+
+
+/**
+ * repeated ExpandedSize expand_requests = 2;
+ * @return {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getExpandRequestsList = function() {
+  return /** @type{!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.connectrpc.conformance.v1.TestCase.ExpandedSize, 2));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpandedSize>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setExpandRequestsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 2, value);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.TestCase.ExpandedSize=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpandedSize}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.addExpandRequests = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 2, opt_value, proto.connectrpc.conformance.v1.TestCase.ExpandedSize, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
 proto.connectrpc.conformance.v1.TestCase.prototype.clearExpandRequestsList = function() {
   return this.setExpandRequestsList([]);
 };
 
 
 /**
- * optional ClientResponseResult expected_response = 3;
- * @return {?proto.connectrpc.conformance.v1.ClientResponseResult}
+ * optional ClientResponseResult expected_response = 3;
+ * @return {?proto.connectrpc.conformance.v1.ClientResponseResult}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getExpectedResponse = function() {
+  return /** @type{?proto.connectrpc.conformance.v1.ClientResponseResult} */ (
+    jspb.Message.getWrapperField(this, connectrpc_conformance_v1_client_compat_pb.ClientResponseResult, 3));
+};
+
+
+/**
+ * @param {?proto.connectrpc.conformance.v1.ClientResponseResult|undefined} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setExpectedResponse = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearExpectedResponse = function() {
+  return this.setExpectedResponse(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.hasExpectedResponse = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * repeated MatrixAxis matrix = 4;
+ * @return {!Array<!proto.connectrpc.conformance.v1.TestCase.MatrixAxis>}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getMatrixList = function() {
+  return /** @type{!Array<!proto.connectrpc.conformance.v1.TestCase.MatrixAxis>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.connectrpc.conformance.v1.TestCase.MatrixAxis, 4));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.TestCase.MatrixAxis>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setMatrixList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 4, value);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.MatrixAxis}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.addMatrix = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 4, opt_value, proto.connectrpc.conformance.v1.TestCase.MatrixAxis, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearMatrixList = function() {
+  return this.setMatrixList([]);
+};
+
+
+/**
+ * optional ResponseMatchers response_matchers = 5;
+ * @return {?proto.connectrpc.conformance.v1.ResponseMatchers}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getResponseMatchers = function() {
+  return /** @type{?proto.connectrpc.conformance.v1.ResponseMatchers} */ (
+    jspb.Message.getWrapperField(this, proto.connectrpc.conformance.v1.ResponseMatchers, 5));
+};
+
+
+/**
+ * @param {?proto.connectrpc.conformance.v1.ResponseMatchers|undefined} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setResponseMatchers = function(value) {
+  return jspb.Message.setWrapperField(this, 5, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearResponseMatchers = function() {
+  return this.setResponseMatchers(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.hasResponseMatchers = function() {
+  return jspb.Message.getField(this, 5) != null;
+};
+
+
+/**
+ * repeated ExpectedResponseOverride expected_response_overrides = 6;
+ * @return {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride>}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getExpectedResponseOverridesList = function() {
+  return /** @type{!Array<!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride, 6));
+};
+
+
+/**
+ * @param {!Array<!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+*/
+proto.connectrpc.conformance.v1.TestCase.prototype.setExpectedResponseOverridesList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 6, value);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.addExpectedResponseOverrides = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 6, opt_value, proto.connectrpc.conformance.v1.TestCase.ExpectedResponseOverride, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearExpectedResponseOverridesList = function() {
+  return this.setExpectedResponseOverridesList([]);
+};
+
+
+/**
+ * repeated string tags = 7;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getTagsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 7));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.setTagsList = function(value) {
+  return jspb.Message.setField(this, 7, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.addTags = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.clearTagsList = function() {
+  return this.setTagsList([]);
+};
+
+
+/**
+ * optional Severity severity = 8;
+ * @return {!proto.connectrpc.conformance.v1.Severity}
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.getSeverity = function() {
+  return /** @type {!proto.connectrpc.conformance.v1.Severity} */ (jspb.Message.getFieldWithDefault(this, 8, 0));
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Severity} value
+ * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ */
+proto.connectrpc.conformance.v1.TestCase.prototype.setSeverity = function(value) {
+  return jspb.Message.setProto3EnumField(this, 8, value);
+};
+
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.repeatedFields_ = [1,5,6,7,8];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.toObject = function(opt_includeInstance) {
+  return proto.connectrpc.conformance.v1.ResponseMatchers.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.connectrpc.conformance.v1.ResponseMatchers} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    errorCodesList: (f = jspb.Message.getRepeatedField(msg, 1)) == null ? undefined : f,
+    errorMessageRegex: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    errorMessagePrefix: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    errorDetails: jspb.Message.getFieldWithDefault(msg, 4, 0),
+    responseHeadersPresentList: (f = jspb.Message.getRepeatedField(msg, 5)) == null ? undefined : f,
+    responseHeadersAbsentList: (f = jspb.Message.getRepeatedField(msg, 6)) == null ? undefined : f,
+    responseTrailersPresentList: (f = jspb.Message.getRepeatedField(msg, 7)) == null ? undefined : f,
+    responseTrailersAbsentList: (f = jspb.Message.getRepeatedField(msg, 8)) == null ? undefined : f,
+    errorMessageSeverity: jspb.Message.getFieldWithDefault(msg, 9, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.connectrpc.conformance.v1.ResponseMatchers;
+  return proto.connectrpc.conformance.v1.ResponseMatchers.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.connectrpc.conformance.v1.ResponseMatchers} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var values = /** @type {!Array<!proto.connectrpc.conformance.v1.Code>} */ (reader.isDelimited() ? reader.readPackedEnum() : [reader.readEnum()]);
+      for (var i = 0; i < values.length; i++) {
+        msg.addErrorCodes(values[i]);
+      }
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setErrorMessageRegex(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setErrorMessagePrefix(value);
+      break;
+    case 4:
+      var value = /** @type {!proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch} */ (reader.readEnum());
+      msg.setErrorDetails(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addResponseHeadersPresent(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addResponseHeadersAbsent(value);
+      break;
+    case 7:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addResponseTrailersPresent(value);
+      break;
+    case 8:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addResponseTrailersAbsent(value);
+      break;
+    case 9:
+      var value = /** @type {!proto.connectrpc.conformance.v1.Severity} */ (reader.readEnum());
+      msg.setErrorMessageSeverity(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.getExpectedResponse = function() {
-  return /** @type{?proto.connectrpc.conformance.v1.ClientResponseResult} */ (
-    jspb.Message.getWrapperField(this, connectrpc_conformance_v1_client_compat_pb.ClientResponseResult, 3));
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.connectrpc.conformance.v1.ResponseMatchers.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
 };
 
 
 /**
- * @param {?proto.connectrpc.conformance.v1.ClientResponseResult|undefined} value
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
-*/
-proto.connectrpc.conformance.v1.TestCase.prototype.setExpectedResponse = function(value) {
-  return jspb.Message.setWrapperField(this, 3, value);
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.connectrpc.conformance.v1.ResponseMatchers} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getErrorCodesList();
+  if (f.length > 0) {
+    writer.writePackedEnum(
+      1,
+      f
+    );
+  }
+  f = message.getErrorMessageRegex();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getErrorMessagePrefix();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getErrorDetails();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      4,
+      f
+    );
+  }
+  f = message.getResponseHeadersPresentList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      5,
+      f
+    );
+  }
+  f = message.getResponseHeadersAbsentList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      6,
+      f
+    );
+  }
+  f = message.getResponseTrailersPresentList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      7,
+      f
+    );
+  }
+  f = message.getResponseTrailersAbsentList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      8,
+      f
+    );
+  }
+  f = message.getErrorMessageSeverity();
+  if (f !== 0.0) {
+    writer.writeEnum(
+      9,
+      f
+    );
+  }
 };
 
 
 /**
- * Clears the message field making it undefined.
- * @return {!proto.connectrpc.conformance.v1.TestCase} returns this
+ * @enum {number}
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.clearExpectedResponse = function() {
-  return this.setExpectedResponse(undefined);
+proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch = {
+  ERROR_DETAILS_MATCH_UNSPECIFIED: 0,
+  ERROR_DETAILS_MATCH_UNORDERED: 1,
+  ERROR_DETAILS_MATCH_CONTAINS: 2
+};
+
+/**
+ * repeated Code error_codes = 1;
+ * @return {!Array<!proto.connectrpc.conformance.v1.Code>}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getErrorCodesList = function() {
+  return /** @type {!Array<!proto.connectrpc.conformance.v1.Code>} */ (jspb.Message.getRepeatedField(this, 1));
 };
 
 
 /**
- * Returns whether this field is set.
- * @return {boolean}
+ * @param {!Array<!proto.connectrpc.conformance.v1.Code>} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
  */
-proto.connectrpc.conformance.v1.TestCase.prototype.hasExpectedResponse = function() {
-  return jspb.Message.getField(this, 3) != null;
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setErrorCodesList = function(value) {
+  return jspb.Message.setField(this, 1, value || []);
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Code} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.addErrorCodes = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 1, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.clearErrorCodesList = function() {
+  return this.setErrorCodesList([]);
+};
+
+
+/**
+ * optional string error_message_regex = 2;
+ * @return {string}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getErrorMessageRegex = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setErrorMessageRegex = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string error_message_prefix = 3;
+ * @return {string}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getErrorMessagePrefix = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
 };
 
 
+/**
+ * @param {string} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setErrorMessagePrefix = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional ErrorDetailsMatch error_details = 4;
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getErrorDetails = function() {
+  return /** @type {!proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.ResponseMatchers.ErrorDetailsMatch} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setErrorDetails = function(value) {
+  return jspb.Message.setProto3EnumField(this, 4, value);
+};
+
+
+/**
+ * repeated string response_headers_present = 5;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getResponseHeadersPresentList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 5));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setResponseHeadersPresentList = function(value) {
+  return jspb.Message.setField(this, 5, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.addResponseHeadersPresent = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 5, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.clearResponseHeadersPresentList = function() {
+  return this.setResponseHeadersPresentList([]);
+};
+
+
+/**
+ * repeated string response_headers_absent = 6;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getResponseHeadersAbsentList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 6));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setResponseHeadersAbsentList = function(value) {
+  return jspb.Message.setField(this, 6, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.addResponseHeadersAbsent = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 6, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.clearResponseHeadersAbsentList = function() {
+  return this.setResponseHeadersAbsentList([]);
+};
+
+
+/**
+ * repeated string response_trailers_present = 7;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getResponseTrailersPresentList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 7));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setResponseTrailersPresentList = function(value) {
+  return jspb.Message.setField(this, 7, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.addResponseTrailersPresent = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 7, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.clearResponseTrailersPresentList = function() {
+  return this.setResponseTrailersPresentList([]);
+};
+
+
+/**
+ * repeated string response_trailers_absent = 8;
+ * @return {!Array<string>}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getResponseTrailersAbsentList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 8));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setResponseTrailersAbsentList = function(value) {
+  return jspb.Message.setField(this, 8, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.addResponseTrailersAbsent = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 8, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.clearResponseTrailersAbsentList = function() {
+  return this.setResponseTrailersAbsentList([]);
+};
+
+
+/**
+ * optional Severity error_message_severity = 9;
+ * @return {!proto.connectrpc.conformance.v1.Severity}
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.getErrorMessageSeverity = function() {
+  return /** @type {!proto.connectrpc.conformance.v1.Severity} */ (jspb.Message.getFieldWithDefault(this, 9, 0));
+};
+
+
+/**
+ * @param {!proto.connectrpc.conformance.v1.Severity} value
+ * @return {!proto.connectrpc.conformance.v1.ResponseMatchers} returns this
+ */
+proto.connectrpc.conformance.v1.ResponseMatchers.prototype.setErrorMessageSeverity = function(value) {
+  return jspb.Message.setProto3EnumField(this, 9, value);
+};
+
+/**
+ * @enum {number}
+ */
+proto.connectrpc.conformance.v1.Severity = {
+  SEVERITY_UNSPECIFIED: 0,
+  SEVERITY_MUST: 1,
+  SEVERITY_SHOULD: 2
+};
+
 goog.object.extend(exports, proto.connectrpc.conformance.v1);